@@ -0,0 +1,76 @@
+package static
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// This checks a comma separated list of glob patterns against both the path
+// relative to the input and the base name of the file.
+func glob(patterns, file string) bool {
+	for _, p := range strings.Split(patterns, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if ok, _ := filepath.Match(filepath.FromSlash(p), file); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(p, filepath.Base(file)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// This decides whether a file that is not markdown should be copied into the
+// output in web mode.
+//
+// Hidden files, and files in hidden directories, are never copied, nor are
+// the templates.  When Include is set a file must match one of its patterns, and a
+// file that matches any pattern in Exclude is skipped.
+func (m *Markdown) include(file string) bool {
+	if m.istemplate(file) {
+		return false
+	}
+	rel := within(m.Input, file)
+	for _, p := range strings.Split(rel, string(filepath.Separator)) {
+		if strings.HasPrefix(p, ".") {
+			return false
+		}
+	}
+	if m.Include != "" && !glob(m.Include, rel) {
+		return false
+	}
+	return !glob(m.Exclude, rel)
+}
+
+// This copies every asset into the same relative location under the output,
+// skipping any that are older than an existing copy unless Force is set.
+func (m *Markdown) copy() {
+	for _, file := range m.assets {
+		dst := filepath.Join(m.Output, within(m.Input, file))
+		if !m.Force {
+			if s, e := stat(file); e == nil {
+				if d, e := stat(dst); e == nil && !s.ModTime().After(d.ModTime()) {
+					continue
+				}
+			}
+		}
+		in, e := open(file)
+		if e != nil {
+			m.errors(e)
+			continue
+		}
+		m.errors(mkdirall(filepath.Dir(dst), os.ModePerm))
+		out, e := create(dst)
+		if e != nil {
+			m.errors(e, in.Close())
+			continue
+		}
+		_, e = io.Copy(out, in)
+		m.errors(e, in.Close(), out.Close())
+	}
+}
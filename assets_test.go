@@ -0,0 +1,56 @@
+package static
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMarkdownAssets(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{
+		"page.md":        "page",
+		"img/logo.png":   "logo",
+		"img/draft.png":  "draft",
+		"notes.txt":      "notes",
+		".git/config":    "config",
+		"public/old.png": "old",
+	})
+	defer cleanup()
+
+	// assets are copied, except hidden, excluded, and output files
+	m := &Markdown{L: &mockLogger{}, Input: d, Web: true, Exclude: "*.txt, img/draft*"}
+	if e := m.Run(Operation(func(b []byte) []byte { return b })); e != nil {
+		t.Error(e)
+	}
+	for f, ok := range map[string]bool{"page.html": true, "img/logo.png": true, "img/draft.png": false, "notes.txt": false, ".git/config": false, "public/old.png": false} {
+		if _, e := os.Stat(filepath.Join(d, "public", f)); (e == nil) != ok {
+			t.Errorf("expected %s to be copied: %v", f, ok)
+		}
+	}
+}
+
+func TestMarkdownAssetsRelative(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{"page.md": "page", ".env": "secret", ".git/config": "secret", "img/logo.png": "logo"})
+	defer cleanup()
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(d)
+
+	// a relative input still skips an absolute output on the next run
+	m := &Markdown{L: &mockLogger{}, Input: ".", Output: filepath.Join(d, "public"), Web: true}
+	for i := 0; i < 2; i++ {
+		if e := m.Run(Operation(func(b []byte) []byte { return b })); e != nil {
+			t.Error(e)
+		}
+	}
+	if _, e := os.Stat(filepath.Join(d, "public", "public")); !os.IsNotExist(e) {
+		t.Errorf("expected the output not to be copied into itself: %v", e)
+	}
+
+	// hidden files keep their leading dot relative to the input, so are skipped
+	for f, ok := range map[string]bool{"page.html": true, "img/logo.png": true, ".env": false, "env": false, ".git/config": false, "git/config": false} {
+		if _, e := os.Stat(filepath.Join(d, "public", f)); (e == nil) != ok {
+			t.Errorf("expected %s to be copied: %v", f, ok)
+		}
+	}
+}
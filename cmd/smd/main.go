@@ -2,7 +2,7 @@ package main
 
 import (
 	"os"
-	"path/filepath"
+	"time"
 
 	"github.com/cdelorme/glog"
 	"github.com/cdelorme/gonf"
@@ -15,17 +15,30 @@ var exit = os.Exit
 var getwd = os.Getwd
 var operate = blackfriday.MarkdownCommon
 
+// The options that configure the renderer rather than the library.
+type config struct {
+	*static.Markdown
+	RendererCmd        string `json:"renderer-cmd"`
+	RendererPersistent bool   `json:"renderer-persistent"`
+	RendererTimeout    int    `json:"renderer-timeout"`
+}
+
 func main() {
 	cwd, _ := getwd()
 
 	smd := &static.Markdown{
-		L:      &glog.Logger{},
-		Input:  cwd,
-		Output: filepath.Join(cwd, "public/"),
+		L:     &glog.Logger{},
+		Input: cwd,
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		smd.Serve = true
+		os.Args = append(os.Args[:1], os.Args[2:]...)
 	}
 
+	c := &config{Markdown: smd}
 	g := &gonf.Config{}
-	g.Target(smd)
+	g.Target(c)
 	g.Description("command line tool for generating static html from markdown")
 	g.Add("web", "parse into individual files matching the original file name", "STATIC_WEB", "--web", "-w")
 	g.Add("title", "the title to give to the processed files", "STATIC_TITLE", "--title", "-t:")
@@ -33,11 +46,45 @@ func main() {
 	g.Add("output", "path to place generated content", "STATIC_OUTPUT", "--output", "-o:")
 	g.Add("version", "optional user-defined version", "STATIC_VERSION", "--version", "-v:")
 	g.Add("template", "path to user-defined template file", "STATIC_TEMPLATE", "--template")
+	g.Add("templates-dir", "path to a directory of templates, layouts and partials", "STATIC_TEMPLATES_DIR", "--templates-dir")
+	g.Add("watch", "keep running and rebuild when markdown or template files change", "STATIC_WATCH", "--watch")
+	g.Add("serve", "preview the output over http with live reload", "STATIC_SERVE", "--serve")
+	g.Add("host", "address the preview server listens on, defaults to 127.0.0.1", "STATIC_HOST", "--host")
+	g.Add("port", "port used by the preview server, defaults to 8080", "STATIC_PORT", "--port", "-p:")
+	g.Add("force", "rebuild every file even if the output is newer", "STATIC_FORCE", "--force", "-f")
+	g.Add("base-url", "absolute url of the site, used to write a sitemap in web mode", "STATIC_BASE_URL", "--base-url", "-u:")
+	g.Add("feed", "comma separated feed formats to write in web mode, atom and rss", "STATIC_FEED", "--feed")
+	g.Add("feed-section", "directory of pages included in feeds, defaults to all", "STATIC_FEED_SECTION", "--feed-section")
+	g.Add("feed-entries", "maximum number of entries in feeds, defaults to 20", "STATIC_FEED_ENTRIES", "--feed-entries")
+	g.Add("search", "write a search index and enable the search box in web mode", "STATIC_SEARCH", "--search", "-s")
+	g.Add("search-fields", "comma separated fields stored in the search index, defaults to title,headings,body", "STATIC_SEARCH_FIELDS", "--search-fields")
+	g.Add("search-body", "maximum characters of body text stored per page in the search index", "STATIC_SEARCH_BODY", "--search-body")
+	g.Add("man", "write a man page for each file, in the section from front matter or the file name", "STATIC_MAN", "--man")
+	g.Add("slides", "split the files into a single html presentation on horizontal rules", "STATIC_SLIDES", "--slides")
+	g.Add("epub", "package the files as an epub instead of a single html file", "STATIC_EPUB", "--epub")
+	g.Add("author", "the author recorded in the epub metadata", "STATIC_AUTHOR", "--author", "-a:")
+	g.Add("self-contained", "inline local images and stylesheets into the book as data uris", "STATIC_SELF_CONTAINED", "--self-contained")
+	g.Add("highlight", "highlight fenced code blocks in common languages", "STATIC_HIGHLIGHT", "--highlight")
+	g.Add("include", "comma separated globs of files to copy in web mode, defaults to all", "STATIC_INCLUDE", "--include")
+	g.Add("exclude", "comma separated globs of files not to copy in web mode", "STATIC_EXCLUDE", "--exclude")
+	g.Add("jobs", "number of pages rendered concurrently in web mode, defaults to GOMAXPROCS", "STATIC_JOBS", "--jobs", "-j:")
+	g.Add("renderer-cmd", "external command that converts markdown on stdin into html on stdout", "STATIC_RENDERER_CMD", "--renderer-cmd")
+	g.Add("renderer-persistent", "keep a single renderer-cmd running and exchange line delimited json", "STATIC_RENDERER_PERSISTENT", "--renderer-persistent")
+	g.Add("renderer-timeout", "seconds allowed to render each file with renderer-cmd, defaults to 30", "STATIC_RENDERER_TIMEOUT", "--renderer-timeout")
 	g.Example("-t template.tmpl -i . -b")
 	g.Example("-t template.tmpl -i src/ -o out/ -r")
+	g.Example("serve -w -p 3000")
 	g.Load()
 
-	if err := smd.Run(operate); err != nil {
+	var r static.Renderer = static.Operation(operate)
+	cmd := &static.Command{Cmd: c.RendererCmd, Persistent: c.RendererPersistent, Timeout: time.Duration(c.RendererTimeout) * time.Second}
+	if c.RendererCmd != "" {
+		r = cmd
+	}
+
+	err := smd.Run(r)
+	cmd.Close()
+	if err != nil {
 		exit(1)
 	}
 }
@@ -0,0 +1,216 @@
+package static
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+var command = exec.CommandContext
+
+// The time allowed to render a single file when no Timeout is supplied.
+const commandTimeout = 30 * time.Second
+
+// A request sent to a persistent Command, one per line.
+type commandRequest struct {
+	Path     string                 `json:"path"`
+	Params   map[string]interface{} `json:"params"`
+	Markdown string                 `json:"markdown"`
+}
+
+// A response read from a persistent Command, one per line.  A non-empty
+// Error fails only the file it was sent for.
+type commandResponse struct {
+	HTML   string                 `json:"html"`
+	Title  string                 `json:"title"`
+	Params map[string]interface{} `json:"params"`
+	Error  string                 `json:"error"`
+}
+
+// A Renderer that converts markdown with an external command, so that any
+// markdown engine or preprocessor may be used.
+//
+// The Cmd is split on whitespace, honouring quotes, and is run directly
+// rather than through a shell.
+//
+// By default a process is started for each file, which receives the markdown
+// on stdin and writes the html to stdout, with the path of the file in the
+// STATIC_PATH environment variable.  A non-zero exit fails the file, and is
+// reported along with anything written to stderr.
+//
+// When Persistent is set a single process is started on the first file, and
+// it reads one json request per line from stdin and writes one json response
+// per line to stdout.  Requests are sent one at a time, and the process is
+// restarted if it stops responding correctly.  Close stops the process.
+//
+// Each file must be rendered within the Timeout, defaulting to 30 seconds, or
+// before the context is canceled, otherwise the process is killed and the
+// file fails, so that a renderer that hangs cannot stall the build.
+type Command struct {
+	Cmd        string
+	Persistent bool
+	Timeout    time.Duration
+
+	mu  sync.Mutex
+	cmd *exec.Cmd
+	in  io.WriteCloser
+	out *bufio.Reader
+}
+
+// This splits a command line on whitespace, treating anything within single
+// or double quotes as part of the same argument.
+func arguments(s string) []string {
+	var l []string
+	var b strings.Builder
+	var q rune
+	var quoted bool
+	for _, r := range s {
+		switch {
+		case q != 0 && r == q:
+			q = 0
+		case q != 0:
+			b.WriteRune(r)
+		case r == '"' || r == '\'':
+			q, quoted = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if b.Len() > 0 || quoted {
+				l = append(l, b.String())
+			}
+			b.Reset()
+			quoted = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() > 0 || quoted {
+		l = append(l, b.String())
+	}
+	return l
+}
+
+// Render converts a single file, using either a new or the persistent
+// process.
+func (c *Command) Render(ctx context.Context, s Source) (Result, error) {
+	args := arguments(c.Cmd)
+	if len(args) == 0 {
+		return Result{}, errors.New("no renderer command supplied")
+	}
+	t := c.Timeout
+	if t <= 0 {
+		t = commandTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, t)
+	defer cancel()
+	if c.Persistent {
+		return c.request(ctx, args, s)
+	}
+	var out, stderr bytes.Buffer
+	cmd := command(ctx, args[0], args[1:]...)
+	cmd.Env = append(os.Environ(), "STATIC_PATH="+s.Path)
+	cmd.Stdin = bytes.NewReader(s.Content)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if e := cmd.Run(); e != nil {
+		if m := strings.TrimSpace(stderr.String()); m != "" {
+			return Result{}, fmt.Errorf("%s: %v: %s", args[0], e, m)
+		}
+		return Result{}, fmt.Errorf("%s: %v", args[0], e)
+	}
+	return Result{HTML: out.Bytes()}, nil
+}
+
+// This sends a single request to the persistent process, starting it when
+// it is not already running, and stopping it if the exchange fails so that
+// the next request starts a new one.
+//
+// The exchange runs separately so that when the context is done the process
+// can be killed, which unblocks the exchange and releases the lock.
+func (c *Command) request(ctx context.Context, args []string, s Source) (Result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cmd == nil {
+		if e := c.start(args); e != nil {
+			return Result{}, e
+		}
+	}
+	b, e := json.Marshal(commandRequest{Path: s.Path, Params: s.Page.Params, Markdown: string(s.Content)})
+	if e != nil {
+		return Result{}, e
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, e := c.in.Write(append(b, '\n'))
+		if e == nil {
+			b, e = c.out.ReadBytes('\n')
+		}
+		done <- e
+	}()
+	select {
+	case e = <-done:
+	case <-ctx.Done():
+		c.cmd.Process.Kill()
+		<-done
+		e = ctx.Err()
+	}
+	var res commandResponse
+	if e == nil {
+		e = json.Unmarshal(b, &res)
+	}
+	if e != nil {
+		c.cmd.Process.Kill()
+		c.stop()
+		return Result{}, fmt.Errorf("%s: %v", args[0], e)
+	}
+	if res.Error != "" {
+		return Result{}, errors.New(res.Error)
+	}
+	return Result{HTML: []byte(res.HTML), Title: res.Title, Params: res.Params}, nil
+}
+
+// This starts the persistent process, which shares our stderr so that any
+// messages it logs are visible.
+func (c *Command) start(args []string) error {
+	cmd := command(context.Background(), args[0], args[1:]...)
+	cmd.Stderr = os.Stderr
+	in, e := cmd.StdinPipe()
+	if e != nil {
+		return e
+	}
+	out, e := cmd.StdoutPipe()
+	if e != nil {
+		return e
+	}
+	if e := cmd.Start(); e != nil {
+		return fmt.Errorf("%s: %v", args[0], e)
+	}
+	c.cmd, c.in, c.out = cmd, in, bufio.NewReader(out)
+	return nil
+}
+
+// This closes stdin and waits for the persistent process to exit, so it
+// should be killed first if it may not be listening.
+func (c *Command) stop() error {
+	if c.cmd == nil {
+		return nil
+	}
+	c.in.Close()
+	e := c.cmd.Wait()
+	c.cmd, c.in, c.out = nil, nil, nil
+	return e
+}
+
+// Close stops the persistent process, if one is running.
+func (c *Command) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stop()
+}
@@ -0,0 +1,110 @@
+package static
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// This is not a real test, but the external renderer run by the tests below,
+// which behaves according to the first argument after the separator.
+func TestCommandHelper(t *testing.T) {
+	if os.Getenv("STATIC_HELPER") == "" {
+		return
+	}
+	switch os.Args[len(os.Args)-1] {
+	case "upper":
+		b, _ := ioutil.ReadAll(os.Stdin)
+		fmt.Print(strings.ToUpper(string(b)) + os.Getenv("STATIC_PATH"))
+	case "fail":
+		fmt.Fprint(os.Stderr, "broken")
+		os.Exit(3)
+	case "persistent":
+		s := bufio.NewScanner(os.Stdin)
+		for s.Scan() {
+			var r commandRequest
+			json.Unmarshal(s.Bytes(), &r)
+			res := commandResponse{HTML: strings.ToUpper(r.Markdown), Title: r.Path}
+			if r.Markdown == "fail" {
+				res.Error = "rejected"
+			} else if r.Markdown == "garbage" {
+				fmt.Println("not json")
+				continue
+			} else if r.Markdown == "hang" {
+				select {}
+			}
+			b, _ := json.Marshal(res)
+			fmt.Println(string(b))
+		}
+	}
+	os.Exit(0)
+}
+
+func TestCommand(t *testing.T) {
+	os.Setenv("STATIC_HELPER", "1")
+	defer os.Unsetenv("STATIC_HELPER")
+	cmd := fmt.Sprintf(`"%s" -test.run=TestCommandHelper -- `, os.Args[0])
+	ctx := context.Background()
+
+	// arguments honour quotes
+	if a := arguments(`a "b c" '' 'd'`); len(a) != 4 || a[1] != "b c" || a[2] != "" || a[3] != "d" {
+		t.Errorf("unexpected arguments: %q", a)
+	}
+
+	// a process per file receives the markdown and path
+	c := &Command{Cmd: cmd + "upper"}
+	if r, e := c.Render(ctx, Source{Path: "a.md", Content: []byte("md")}); e != nil || string(r.HTML) != "MDa.md" {
+		t.Errorf("unexpected result: %q %v", r.HTML, e)
+	}
+
+	// non-zero exits fail with stderr
+	c = &Command{Cmd: cmd + "fail"}
+	if _, e := c.Render(ctx, Source{Path: "a.md"}); e == nil || !strings.Contains(e.Error(), "broken") {
+		t.Errorf("expected failure with stderr, got %v", e)
+	}
+
+	// a persistent process handles every request
+	c = &Command{Cmd: cmd + "persistent", Persistent: true}
+	defer c.Close()
+	for _, f := range []string{"a.md", "b.md"} {
+		if r, e := c.Render(ctx, Source{Path: f, Content: []byte("md")}); e != nil || string(r.HTML) != "MD" || r.Title != f {
+			t.Errorf("unexpected result: %+v %v", r, e)
+		}
+	}
+	p := c.cmd.Process.Pid
+
+	// errors in a response fail only that file
+	if _, e := c.Render(ctx, Source{Content: []byte("fail")}); e == nil || e.Error() != "rejected" || c.cmd.Process.Pid != p {
+		t.Errorf("expected rejection from the same process, got %v", e)
+	}
+
+	// invalid responses restart the process
+	if _, e := c.Render(ctx, Source{Content: []byte("garbage")}); e == nil || c.cmd != nil {
+		t.Errorf("expected failure to stop the process, got %v", e)
+	}
+	if r, e := c.Render(ctx, Source{Content: []byte("md")}); e != nil || string(r.HTML) != "MD" {
+		t.Errorf("expected a new process, got %q %v", r.HTML, e)
+	}
+
+	// a process that hangs is killed after the timeout, or when the context
+	// is canceled, and the next request starts a new one
+	c.Timeout = 100 * time.Millisecond
+	if _, e := c.Render(ctx, Source{Content: []byte("hang")}); e == nil || c.cmd != nil {
+		t.Errorf("expected a timeout to stop the process, got %v", e)
+	}
+	c.Timeout = 0
+	canceled, cancel := context.WithCancel(ctx)
+	time.AfterFunc(100*time.Millisecond, cancel)
+	if _, e := c.Render(canceled, Source{Content: []byte("hang")}); e == nil || c.cmd != nil {
+		t.Errorf("expected cancelation to stop the process, got %v", e)
+	}
+	if r, e := c.Render(ctx, Source{Content: []byte("md")}); e != nil || string(r.HTML) != "MD" {
+		t.Errorf("expected a new process, got %q %v", r.HTML, e)
+	}
+}
@@ -0,0 +1,377 @@
+package static
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha1"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var entities = regexp.MustCompile(`&([a-zA-Z][a-zA-Z0-9]*);`)
+var void = regexp.MustCompile(`(?i)<(area|base|br|col|embed|hr|img|input|link|meta|source|track|wbr)(\s[^>]*?)?\s*/?>`)
+var imgsrc = regexp.MustCompile(`(?i)(<img\s[^>]*?src\s*=\s*")([^"]*)(")`)
+
+// The language declared by the package, since markdown carries none.
+const locale = "en"
+
+// The stylesheet shared by every chapter of an epub, which leaves most styling
+// to the reader but keeps code legible and matches the highlighting classes.
+const stylesheet = `pre { white-space: pre-wrap; }
+code { font-family: monospace; }
+pre code { display: block; padding: 0.5em; background: #f4f4f4; }
+img { max-width: 100%; }
+.hl-k, .hl-d { color: #a71d5d; }
+.hl-t, .hl-m { color: #0086b3; }
+.hl-s { color: #183691; }
+.hl-n { color: #0086b3; }
+.hl-a, .hl-i { color: #63a35c; }
+.hl-v { color: #795da3; }
+.hl-c { color: #969896; font-style: italic; }
+`
+
+const container = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+	<rootfiles>
+		<rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+	</rootfiles>
+</container>
+`
+
+type opfIdentifier struct {
+	ID    string `xml:"id,attr"`
+	Value string `xml:",chardata"`
+}
+
+type opfMeta struct {
+	Property string `xml:"property,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type opfMetadata struct {
+	DC         string        `xml:"xmlns:dc,attr"`
+	Identifier opfIdentifier `xml:"dc:identifier"`
+	Title      string        `xml:"dc:title"`
+	Language   string        `xml:"dc:language"`
+	Creator    string        `xml:"dc:creator,omitempty"`
+	Meta       []opfMeta     `xml:"meta"`
+}
+
+type opfItem struct {
+	ID         string `xml:"id,attr"`
+	Href       string `xml:"href,attr"`
+	MediaType  string `xml:"media-type,attr"`
+	Properties string `xml:"properties,attr,omitempty"`
+}
+
+type opfItemRef struct {
+	IDRef string `xml:"idref,attr"`
+}
+
+type opfPackage struct {
+	XMLName    xml.Name     `xml:"package"`
+	Xmlns      string       `xml:"xmlns,attr"`
+	Version    string       `xml:"version,attr"`
+	Identifier string       `xml:"unique-identifier,attr"`
+	Metadata   opfMetadata  `xml:"metadata"`
+	Manifest   []opfItem    `xml:"manifest>item"`
+	Spine      []opfItemRef `xml:"spine>itemref"`
+}
+
+type navLink struct {
+	Href  string `xml:"href,attr"`
+	Title string `xml:",chardata"`
+}
+
+type navItem struct {
+	Link     navLink  `xml:"a"`
+	Children *navTree `xml:"ol,omitempty"`
+}
+
+// A nested list in the navigation, which is omitted rather than left empty
+// since epub requires every list to have at least one item.
+type navTree struct {
+	Items []navItem `xml:"li"`
+}
+
+type navList struct {
+	Type  string    `xml:"epub:type,attr"`
+	Items []navItem `xml:"ol>li"`
+}
+
+type navDocument struct {
+	XMLName xml.Name `xml:"html"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Epub    string   `xml:"xmlns:epub,attr"`
+	Title   string   `xml:"head>title"`
+	Nav     navList  `xml:"body>nav"`
+}
+
+// A single xhtml document in the epub, which is written to a section of the
+// temporary file so that links to later chapters can be resolved.
+type section struct {
+	name     string
+	title    string
+	offset   int64
+	length   int64
+	headings []*Heading
+}
+
+// An image copied into the epub, from the file it was read from, along with
+// its media type.
+type image struct {
+	file string
+	name string
+	kind string
+}
+
+// This converts html into xhtml that an xml parser accepts, by closing void
+// elements and replacing named entities other than those xml predefines with
+// numeric references.
+func xhtml(b []byte) []byte {
+	b = void.ReplaceAll(b, []byte("<$1$2 />"))
+	return entities.ReplaceAllFunc(b, func(e []byte) []byte {
+		switch string(e) {
+		case "&amp;", "&lt;", "&gt;", "&quot;", "&apos;":
+			return e
+		}
+		u := html.UnescapeString(string(e))
+		if u == string(e) {
+			return []byte("&amp;" + string(e[1:]))
+		}
+		var r strings.Builder
+		for _, c := range u {
+			fmt.Fprintf(&r, "&#%d;", c)
+		}
+		return []byte(r.String())
+	})
+}
+
+// This adds every local image in the html to the epub, replacing the source
+// with the path within the archive.  Each image file is only added once, and
+// images that cannot be found, or whose type is unknown, are left alone and
+// reported.
+func (m *Markdown) images(dir string, b []byte, l *[]image) []byte {
+	return imgsrc.ReplaceAllFunc(b, func(a []byte) []byte {
+		s := imgsrc.FindSubmatch(a)
+		src := html.UnescapeString(string(s[2]))
+		p, _ := target(src)
+		u, e := url.PathUnescape(p)
+		if p == "" || e != nil {
+			return a
+		}
+		file := filepath.Join(dir, filepath.FromSlash(u))
+		for _, i := range *l {
+			if i.file == file {
+				return []byte(string(s[1]) + i.name + string(s[3]))
+			}
+		}
+		t := mime.TypeByExtension(filepath.Ext(file))
+		if i := strings.Index(t, ";"); i >= 0 {
+			t = t[:i]
+		}
+		if f, e := stat(file); e != nil || f.IsDir() || t == "" {
+			m.L.Info("Unable to include image %s in %s", src, dir)
+			return a
+		}
+		i := image{file: file, name: fmt.Sprintf("images/%d%s", len(*l)+1, strings.ToLower(filepath.Ext(file))), kind: t}
+		*l = append(*l, i)
+		return []byte(string(s[1]) + i.name + string(s[3]))
+	})
+}
+
+// This builds the entries in the navigation document for a chapter from its
+// nested headings.
+func (d section) nav(h []*Heading) []navItem {
+	var l []navItem
+	for _, c := range h {
+		i := navItem{Link: navLink{Href: d.name + "#" + c.ID, Title: c.Title}}
+		if len(c.Children) > 0 {
+			i.Children = &navTree{Items: d.nav(c.Children)}
+		}
+		l = append(l, i)
+	}
+	return l
+}
+
+// This writes a single file into the archive, compressed.
+func write(z *zip.Writer, name string, r io.Reader) error {
+	w, e := z.Create(name)
+	if e != nil {
+		return e
+	}
+	_, e = io.Copy(w, r)
+	return e
+}
+
+// This packages the files as an epub 3 archive, with one xhtml document per
+// file, a navigation document built from the headings, and every local image.
+//
+// Like book mode each file is rendered in order into a temporary file, since
+// links between files may point at headings in files that have not yet been
+// processed.  Each chapter is then copied into the archive from its section of
+// the temporary file, resolving links to the chapter and anchor they point at.
+//
+// The identifier is derived from the Title and Version, so that rebuilding the
+// same version produces the same book, and the modified time is that of the
+// newest markdown file.  Templates are not used, since readers apply their own
+// styling.
+func (m *Markdown) epub(r Renderer) error {
+	tmp, e := tempfile(os.TempDir(), "static-epub")
+	if e != nil {
+		return e
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	w := bufio.NewWriter(tmp)
+	a := &anchors{}
+	var docs []section
+	var imgs []image
+	var offset int64
+	var modified time.Time
+	for i := range m.files {
+		in, e := open(m.files[i])
+		if e != nil {
+			m.errors(e)
+			continue
+		}
+		d, e := readall(in)
+		m.errors(in.Close())
+		if e != nil {
+			m.errors(e)
+			continue
+		}
+		if _, d, e = m.render(r, m.files[i], d); e != nil {
+			m.errors(e)
+			continue
+		}
+		if f, e := stat(m.files[i]); e == nil && f.ModTime().After(modified) {
+			modified = f.ModTime()
+		}
+		n := strings.Trim(filepath.ToSlash(strings.TrimSuffix(within(m.Input, m.files[i]), filepath.Ext(m.files[i]))), "/")
+		doc := section{name: fmt.Sprintf("chapter-%d.xhtml", len(docs)+1), title: path.Base(n), offset: offset}
+		id := a.begin(m.files[i], strings.Replace(n, "/", " ", -1))
+		a.current.doc = doc.name
+		start := len(a.headings)
+		d = a.anchor(m.crosslink(m.files[i], d))
+		d = xhtml(m.images(filepath.Dir(m.files[i]), d, &imgs))
+		doc.headings = (&anchors{headings: a.headings[start:]}).toc()
+		if len(a.headings) > start {
+			doc.title = a.headings[start].Title
+		}
+		c, e := fmt.Fprintf(w, `<a id="%s"></a>`, id)
+		if e == nil {
+			var n int
+			n, e = w.Write(d)
+			c += n
+		}
+		if e != nil {
+			return e
+		}
+		doc.length = int64(c)
+		offset += doc.length
+		docs = append(docs, doc)
+	}
+	if e := w.Flush(); e != nil {
+		return e
+	}
+	if modified.IsZero() {
+		modified = time.Now()
+	}
+
+	m.errors(mkdirall(filepath.Dir(m.Output), os.ModePerm))
+	out, e := create(m.Output)
+	if e != nil {
+		return e
+	}
+	defer out.Close()
+	z := zip.NewWriter(out)
+	mt, e := z.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if e != nil {
+		return e
+	}
+	if _, e := io.WriteString(mt, "application/epub+zip"); e != nil {
+		return e
+	}
+	if e := write(z, "META-INF/container.xml", strings.NewReader(container)); e != nil {
+		return e
+	}
+	if e := write(z, "OEBPS/style.css", strings.NewReader(stylesheet)); e != nil {
+		return e
+	}
+
+	p := opfPackage{
+		Xmlns:      "http://www.idpf.org/2007/opf",
+		Version:    "3.0",
+		Identifier: "id",
+		Metadata: opfMetadata{
+			DC:         "http://purl.org/dc/elements/1.1/",
+			Identifier: opfIdentifier{ID: "id", Value: fmt.Sprintf("urn:sha1:%x", sha1.Sum([]byte(m.Title+"\x00"+m.Version)))},
+			Title:      m.Title,
+			Language:   locale,
+			Creator:    m.Author,
+			Meta:       []opfMeta{{Property: "dcterms:modified", Value: modified.UTC().Format("2006-01-02T15:04:05Z")}},
+		},
+		Manifest: []opfItem{
+			{ID: "nav", Href: "nav.xhtml", MediaType: "application/xhtml+xml", Properties: "nav"},
+			{ID: "style", Href: "style.css", MediaType: "text/css"},
+		},
+	}
+	if m.Version != "" {
+		p.Metadata.Meta = append(p.Metadata.Meta, opfMeta{Property: "schema:version", Value: m.Version})
+	}
+	nav := navDocument{Xmlns: "http://www.w3.org/1999/xhtml", Epub: "http://www.idpf.org/2007/ops", Title: m.Title, Nav: navList{Type: "toc"}}
+	for i, d := range docs {
+		cw, e := z.Create("OEBPS/" + d.name)
+		if e != nil {
+			return e
+		}
+		fmt.Fprintf(cw, "%s<!DOCTYPE html>\n<html xmlns=\"http://www.w3.org/1999/xhtml\" xmlns:epub=\"http://www.idpf.org/2007/ops\" xml:lang=\"%s\">\n<head><title>%s</title><link rel=\"stylesheet\" type=\"text/css\" href=\"style.css\" /></head>\n<body>\n", xml.Header, locale, html.EscapeString(d.title))
+		if e := a.resolve(cw, io.NewSectionReader(tmp, d.offset, d.length), m.L.Info); e != nil {
+			return e
+		}
+		if _, e := io.WriteString(cw, "\n</body>\n</html>\n"); e != nil {
+			return e
+		}
+		id := fmt.Sprintf("chapter-%d", i+1)
+		p.Manifest = append(p.Manifest, opfItem{ID: id, Href: d.name, MediaType: "application/xhtml+xml"})
+		p.Spine = append(p.Spine, opfItemRef{IDRef: id})
+		if len(d.headings) == 0 {
+			nav.Nav.Items = append(nav.Nav.Items, navItem{Link: navLink{Href: d.name, Title: d.title}})
+		} else {
+			nav.Nav.Items = append(nav.Nav.Items, d.nav(d.headings)...)
+		}
+	}
+	for i, img := range imgs {
+		in, e := open(img.file)
+		if e != nil {
+			return e
+		}
+		e = write(z, "OEBPS/"+img.name, in)
+		m.errors(in.Close())
+		if e != nil {
+			return e
+		}
+		p.Manifest = append(p.Manifest, opfItem{ID: fmt.Sprintf("image-%d", i+1), Href: img.name, MediaType: img.kind})
+	}
+	for name, v := range map[string]interface{}{"OEBPS/content.opf": p, "OEBPS/nav.xhtml": nav} {
+		b, e := xml.MarshalIndent(v, "", "\t")
+		if e != nil {
+			return e
+		}
+		if e := write(z, name, strings.NewReader(xml.Header+string(b)+"\n")); e != nil {
+			return e
+		}
+	}
+	return z.Close()
+}
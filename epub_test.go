@@ -0,0 +1,126 @@
+package static
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownEpub(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{
+		"a.md":    `<h1>First</h1><p>&ldquo;quoted&rdquo;<br><img src="img.png"><img src="missing.png"></p><p><a href="b.md#second">next</a></p>`,
+		"b.md":    `<h1>Second</h1><h2>Nested</h2><p><img src="img.png"></p>`,
+		"c.md":    `no headings`,
+		"img.png": "\x89PNG",
+	})
+	defer cleanup()
+
+	m := &Markdown{L: &mockLogger{}, Input: d, Title: "Manual", Version: "1.0", Author: "Casey", Epub: true}
+	if e := m.Run(Operation(func(b []byte) []byte { return b })); e != nil {
+		t.Fatal(e)
+	}
+	if filepath.Ext(m.Output) != ".epub" {
+		t.Errorf("unexpected output: %s", m.Output)
+	}
+	z, e := zip.OpenReader(m.Output)
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer z.Close()
+
+	// the mimetype is first and stored uncompressed
+	files := make(map[string]string)
+	for i, f := range z.File {
+		r, e := f.Open()
+		if e != nil {
+			t.Fatal(e)
+		}
+		b, _ := ioutil.ReadAll(r)
+		r.Close()
+		files[f.Name] = string(b)
+		if i == 0 && (f.Name != "mimetype" || f.Method != zip.Store || string(b) != "application/epub+zip") {
+			t.Errorf("expected an uncompressed mimetype first, got %s", f.Name)
+		}
+	}
+
+	// every xml document is well formed
+	for name, b := range files {
+		if !strings.HasSuffix(name, ".xml") && !strings.HasSuffix(name, ".opf") && !strings.HasSuffix(name, ".xhtml") {
+			continue
+		}
+		dec := xml.NewDecoder(strings.NewReader(b))
+		for {
+			if _, e := dec.Token(); e == io.EOF {
+				break
+			} else if e != nil {
+				t.Errorf("%s is not well formed: %v", name, e)
+				break
+			}
+		}
+	}
+
+	// the container points at the package, and the package lists every file
+	var c struct {
+		Rootfile struct {
+			Path string `xml:"full-path,attr"`
+		} `xml:"rootfiles>rootfile"`
+	}
+	xml.Unmarshal([]byte(files["META-INF/container.xml"]), &c)
+	var p struct {
+		Identifier string `xml:"unique-identifier,attr"`
+		Metadata   string `xml:",innerxml"`
+		Manifest   []struct {
+			ID         string `xml:"id,attr"`
+			Href       string `xml:"href,attr"`
+			Properties string `xml:"properties,attr"`
+		} `xml:"manifest>item"`
+		Spine []struct {
+			IDRef string `xml:"idref,attr"`
+		} `xml:"spine>itemref"`
+	}
+	if e := xml.Unmarshal([]byte(files[c.Rootfile.Path]), &p); e != nil {
+		t.Fatal(e)
+	}
+	ids := make(map[string]bool)
+	var nav bool
+	for _, i := range p.Manifest {
+		ids[i.ID] = true
+		nav = nav || i.Properties == "nav"
+		if _, ok := files[filepath.ToSlash(filepath.Join("OEBPS", i.Href))]; !ok {
+			t.Errorf("missing manifest item %s", i.Href)
+		}
+	}
+	if !nav || len(p.Manifest) != 6 || len(p.Spine) != 3 {
+		t.Errorf("unexpected manifest or spine: %+v", p)
+	}
+	for _, s := range p.Spine {
+		if !ids[s.IDRef] {
+			t.Errorf("spine references a missing item: %s", s.IDRef)
+		}
+	}
+	for _, s := range []string{`<dc:identifier id="id">`, `<dc:title>Manual</dc:title>`, `<dc:creator>Casey</dc:creator>`, `property="dcterms:modified"`, `property="schema:version">1.0<`} {
+		if !strings.Contains(files[c.Rootfile.Path], s) {
+			t.Errorf("expected %s in the package", s)
+		}
+	}
+
+	// links, images and the navigation point at the chapters
+	if a := files["OEBPS/chapter-1.xhtml"]; !strings.Contains(a, `href="chapter-2.xhtml#second"`) || !strings.Contains(a, `src="images/1.png"`) || !strings.Contains(a, `src="missing.png"`) || !strings.Contains(a, "&#8220;quoted") {
+		t.Errorf("unexpected chapter: %s", a)
+	}
+	if b := files["OEBPS/chapter-2.xhtml"]; !strings.Contains(b, `src="images/1.png"`) {
+		t.Errorf("expected the image to be shared: %s", b)
+	}
+	for _, s := range []string{`href="chapter-1.xhtml#first">First<`, `<ol>`, `href="chapter-2.xhtml#nested">Nested<`, `href="chapter-3.xhtml">c<`} {
+		if !strings.Contains(files["OEBPS/nav.xhtml"], s) {
+			t.Errorf("expected %s in the navigation: %s", s, files["OEBPS/nav.xhtml"])
+		}
+	}
+	if strings.Contains(files["OEBPS/nav.xhtml"], "<ol></ol>") {
+		t.Error("expected no empty lists in the navigation")
+	}
+}
@@ -0,0 +1,257 @@
+package static
+
+import (
+	"encoding/xml"
+	"errors"
+	"html"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var paragraph = regexp.MustCompile(`(?is)<p[^>]*>(.*?)</p>`)
+var references = regexp.MustCompile(`(?i)(\s(?:href|src)\s*=\s*")([^"]*)(")`)
+
+// The number of entries in a feed when none has been supplied.
+const entries = 20
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr,omitempty"`
+	Body string `xml:",chardata"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Author  *atomAuthor `xml:"author,omitempty"`
+	Summary atomText    `xml:"summary"`
+	Content atomText    `xml:"content"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type rssContent struct {
+	Body string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string     `xml:"title"`
+	Link        string     `xml:"link"`
+	GUID        string     `xml:"guid"`
+	PubDate     string     `xml:"pubDate"`
+	Creator     string     `xml:"http://purl.org/dc/elements/1.1/ creator,omitempty"`
+	Description string     `xml:"description"`
+	Content     rssContent `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+// An entry in a feed, with the rendered html of the page.
+type entry struct {
+	Link
+	summary string
+	content string
+}
+
+// This returns the html for a single file the same way as its page, without
+// the template, which is only rendered when neither this build nor the
+// previous one rendered the page.
+func (m *Markdown) content(file string, r Renderer) ([]byte, error) {
+	m.mu.Lock()
+	b, ok := m.rendered[file]
+	m.mu.Unlock()
+	if ok {
+		return b, nil
+	}
+	in, e := open(file)
+	if e != nil {
+		return nil, e
+	}
+	b, e = readall(in)
+	m.errors(in.Close())
+	if e != nil {
+		return nil, e
+	}
+	if _, b, e = m.render(r, file, b); e != nil {
+		return nil, e
+	}
+	b = (&anchors{}).anchor(m.relink(file, b))
+	m.keep(file, b)
+	return b, nil
+}
+
+// This keeps the html of a page for the search index and feeds, and is safe
+// to call from concurrent workers.
+func (m *Markdown) keep(file string, b []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.rendered == nil {
+		m.rendered = make(map[string][]byte)
+	}
+	m.rendered[file] = b
+}
+
+// This uses the description from the front matter as the summary, or the
+// plain text of the first paragraph when there is none.
+func summary(l Link, b []byte) string {
+	if l.Page.Description != "" {
+		return l.Page.Description
+	}
+	if p := paragraph.FindSubmatch(b); p != nil {
+		return strings.TrimSpace(html.UnescapeString(tags.ReplaceAllString(string(p[1]), "")))
+	}
+	return ""
+}
+
+// This makes every link and image in the html of a page absolute, resolving
+// relative urls against the url of the page, since feed readers show the
+// content away from the site.
+func (m *Markdown) rebase(p string, b string) string {
+	base, e := url.Parse(m.absolute(p))
+	if e != nil {
+		return b
+	}
+	return references.ReplaceAllStringFunc(b, func(a string) string {
+		s := references.FindStringSubmatch(a)
+		u, e := url.Parse(html.UnescapeString(s[2]))
+		if e != nil {
+			return a
+		}
+		return s[1] + html.EscapeString(base.ResolveReference(u).String()) + s[3]
+	})
+}
+
+// This collects the newest pages in the feed section, excluding drafts, with
+// the content for each, which is shared with the search index and only made
+// absolute for the feeds.
+//
+// The links and files share the same order, so the file for each link is found
+// by its index.
+func (m *Markdown) entries(links []Link, r Renderer) []entry {
+	section := strings.Trim(filepath.ToSlash(m.FeedSection), "/")
+	var l []int
+	for i := range links {
+		if links[i].Page.Draft || (section != "" && links[i].Dir != section && !strings.HasPrefix(links[i].Dir, section+"/")) {
+			continue
+		}
+		l = append(l, i)
+	}
+	sort.SliceStable(l, func(a, b int) bool { return links[l[a]].Date.After(links[l[b]].Date) })
+	n := m.FeedEntries
+	if n <= 0 {
+		n = entries
+	}
+	if len(l) > n {
+		l = l[:n]
+	}
+	var es []entry
+	for _, i := range l {
+		b, e := m.content(m.files[i], r)
+		if e != nil {
+			m.errors(e)
+			continue
+		}
+		es = append(es, entry{Link: links[i], summary: summary(links[i], b), content: m.rebase(links[i].Path, string(b))})
+	}
+	return es
+}
+
+// This writes an atom feed to feed.xml and a rss feed to rss.xml, according to
+// the comma separated list of formats in Feed.
+//
+// The author of a page is written as the name in atom, and as `dc:creator` in
+// rss, since its own author element must hold an email address.
+//
+// Feeds require absolute urls, so a BaseURL must be supplied.
+func (m *Markdown) feeds(links []Link, r Renderer) error {
+	if m.Feed == "" {
+		return nil
+	}
+	if m.BaseURL == "" {
+		return errors.New("feeds require a base url")
+	}
+	es := m.entries(links, r)
+	var updated time.Time
+	for i := range es {
+		if es[i].Date.After(updated) {
+			updated = es[i].Date
+		}
+	}
+	for _, f := range strings.Split(m.Feed, ",") {
+		switch strings.TrimSpace(f) {
+		case "atom":
+			a := atomFeed{
+				Xmlns:   "http://www.w3.org/2005/Atom",
+				Title:   m.Title,
+				ID:      m.absolute(""),
+				Updated: updated.UTC().Format(time.RFC3339),
+				Links:   []atomLink{{Href: m.absolute("feed.xml"), Rel: "self"}, {Href: m.absolute("")}},
+			}
+			for _, e := range es {
+				ae := atomEntry{
+					Title:   e.Title,
+					ID:      m.absolute(e.Path),
+					Updated: e.Date.UTC().Format(time.RFC3339),
+					Link:    atomLink{Href: m.absolute(e.Path)},
+					Summary: atomText{Type: "text", Body: e.summary},
+					Content: atomText{Type: "html", Body: e.content},
+				}
+				if e.Page.Author != "" {
+					ae.Author = &atomAuthor{Name: e.Page.Author}
+				}
+				a.Entries = append(a.Entries, ae)
+			}
+			m.errors(m.xml("feed.xml", a))
+		case "rss":
+			r := rssFeed{Version: "2.0", Channel: rssChannel{Title: m.Title, Link: m.absolute(""), Description: m.Title}}
+			for _, e := range es {
+				r.Channel.Items = append(r.Channel.Items, rssItem{
+					Title:       e.Title,
+					Link:        m.absolute(e.Path),
+					GUID:        m.absolute(e.Path),
+					PubDate:     e.Date.UTC().Format(time.RFC1123Z),
+					Creator:     e.Page.Author,
+					Description: e.summary,
+					Content:     rssContent{Body: e.content},
+				})
+			}
+			m.errors(m.xml("rss.xml", r))
+		default:
+			m.errors(errors.New("unknown feed format: " + f))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package static
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownFeeds(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{
+		"index.md":  "---\ndate: 2017-06-01\n---\nhome",
+		"blog/a.md": "---\ntitle: Oldest\ndate: 2017-01-01\n---\nold",
+		"blog/b.md": "---\ntitle: Newest\ndate: 2017-03-01\nauthor: Casey\n---\n<p>first &amp; foremost</p>",
+		"blog/c.md": "---\ntitle: Middle\ndate: 2017-02-01\ndescription: summary\n---\n<a href=\"a.md#top\">middle</a><img src=\"../logo.png\"><a href=\"#end\">end</a>",
+		"blog/d.md": "---\ntitle: Draft\ndate: 2017-05-01\ndraft: true\n---\ndraft",
+	})
+	defer cleanup()
+
+	// feeds without a base url fail
+	m := &Markdown{L: &mockLogger{}, Input: d, Web: true, Feed: "atom,rss", FeedSection: "blog", FeedEntries: 2}
+	if e := m.Run(Operation(func(b []byte) []byte { return b })); e == nil {
+		t.Error("expected feeds without a base url to fail")
+	}
+
+	// the newest entries in the section are written to both feeds, and
+	// together with the search index each page is rendered once
+	var rendered int
+	o := Operation(func(b []byte) []byte { rendered++; return b })
+	m.BaseURL, m.Search, m.Force = "https://example.com", true, true
+	if e := m.Run(o); e != nil {
+		t.Fatal(e)
+	}
+	if rendered != 5 {
+		t.Errorf("expected each page to be rendered once, got %d", rendered)
+	}
+	var a atomFeed
+	b, _ := ioutil.ReadFile(filepath.Join(d, "public", "feed.xml"))
+	if e := xml.Unmarshal(b, &a); e != nil {
+		t.Fatal(e)
+	}
+	if len(a.Entries) != 2 || a.Entries[0].Title != "Newest" || a.Entries[1].Title != "Middle" || a.Updated != "2017-03-01T00:00:00Z" {
+		t.Fatalf("unexpected atom feed: %s", b)
+	}
+	if a.Entries[0].ID != "https://example.com/blog/b.html" || a.Entries[0].Summary.Body != "first & foremost" || a.Entries[0].Content.Body != "<p>first &amp; foremost</p>" || a.Entries[0].Author.Name != "Casey" || a.Entries[1].Summary.Body != "summary" || !strings.Contains(a.Entries[1].Content.Body, `src="https://example.com/logo.png"`) {
+		t.Errorf("unexpected atom entries: %#v", a.Entries)
+	}
+	var r rssFeed
+	b, _ = ioutil.ReadFile(filepath.Join(d, "public", "rss.xml"))
+	if e := xml.Unmarshal(b, &r); e != nil {
+		t.Fatal(e)
+	}
+	if len(r.Channel.Items) != 2 || r.Channel.Items[0].Link != "https://example.com/blog/b.html" || r.Channel.Items[0].Creator != "Casey" || r.Channel.Items[1].Content.Body != `<a href="https://example.com/blog/a.html#top">middle</a><img src="https://example.com/logo.png"><a href="https://example.com/blog/c.html#end">end</a>` {
+		t.Errorf("unexpected rss feed: %s", b)
+	}
+
+	// unmodified pages are not rendered again for the feeds
+	m.Force = false
+	if e := m.Run(o); e != nil {
+		t.Fatal(e)
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(d, "public", "rss.xml")); rendered != 5 || !strings.Contains(string(b), "foremost") {
+		t.Errorf("expected feeds from the previous build without rendering, got %d renders: %s", rendered, b)
+	}
+}
@@ -0,0 +1,341 @@
+package static
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// List of date formats accepted by front matter, in the order they are tried.
+var dates = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// The start of a json object, which is either empty or opens with a key, so
+// that markdown beginning with a brace is not mistaken for front matter.
+var object = regexp.MustCompile(`^\{\s*["}]`)
+
+// A top level yaml key, followed by its value or the end of the line.
+var pair = regexp.MustCompile(`^[A-Za-z0-9_"'][^:]*:(\s|$)`)
+
+// The metadata for a single markdown file, read from the front matter at the
+// top of the file.
+//
+// Every key is available in Params, including those that also populate one of
+// the typed fields, so templates may use either.
+type Page struct {
+	Title       string
+	Description string
+	Date        time.Time
+	Author      string
+	Weight      int
+	Draft       bool
+	Layout      string
+	Params      map[string]interface{}
+}
+
+// This populates the typed fields from the matching keys in Params, ignoring
+// values of the wrong type.
+func (p *Page) typed() error {
+	p.Title, _ = p.Params["title"].(string)
+	p.Description, _ = p.Params["description"].(string)
+	p.Author, _ = p.Params["author"].(string)
+	p.Layout, _ = p.Params["layout"].(string)
+	p.Draft, _ = p.Params["draft"].(bool)
+	switch w := p.Params["weight"].(type) {
+	case int:
+		p.Weight = w
+	case float64:
+		p.Weight = int(w)
+	}
+	if d, ok := p.Params["date"].(string); ok {
+		for i := range dates {
+			if t, e := time.Parse(dates[i], d); e == nil {
+				p.Date = t
+				return nil
+			}
+		}
+		return fmt.Errorf("unrecognized date: %s", d)
+	}
+	return nil
+}
+
+// This separates the front matter from the markdown, returning the page and
+// the remaining markdown.
+//
+// YAML front matter is fenced by `---`, must open with a key and holds only
+// keys at the top level, TOML is fenced by `+++`, and JSON is a single object
+// at the very start of the file, which must open with a quoted key or be
+// empty.  Files without front matter return an empty page and the original
+// bytes.
+//
+// The parsers are deliberately minimal, supporting scalars, lists, and a
+// single level of nesting, which avoids any additional dependencies.
+func frontmatter(b []byte) (Page, []byte, error) {
+	p := Page{Params: make(map[string]interface{})}
+	var e error
+	switch {
+	case object.Match(b):
+		d := json.NewDecoder(bytes.NewReader(b))
+		d.UseNumber()
+		if e = d.Decode(&p.Params); e != nil {
+			return p, b, e
+		}
+		for k, v := range p.Params {
+			p.Params[k] = number(v)
+		}
+		b = b[d.InputOffset():]
+	case bytes.HasPrefix(b, []byte("---\n")), bytes.HasPrefix(b, []byte("---\r\n")):
+		f, r, ok := fence(b, "---")
+		if !ok || !keyed(f) {
+			return p, b, nil
+		}
+		b, e = r, yaml(f, p.Params)
+	case bytes.HasPrefix(b, []byte("+++\n")), bytes.HasPrefix(b, []byte("+++\r\n")):
+		f, r, ok := fence(b, "+++")
+		if !ok {
+			return p, b, nil
+		}
+		b, e = r, toml(f, p.Params)
+	default:
+		return p, b, nil
+	}
+	if e != nil {
+		return p, b, e
+	}
+	return p, bytes.TrimLeft(b, "\r\n"), p.typed()
+}
+
+// This splits the lines between the opening and closing fence from the rest
+// of the file, and reports whether a closing fence was found.
+//
+// A file that opens with a fence but never closes it is simply markdown that
+// starts with a horizontal rule.
+func fence(b []byte, f string) ([]byte, []byte, bool) {
+	all := b
+	b = b[bytes.IndexByte(b, '\n')+1:]
+	for i := 0; i < len(b); {
+		n := bytes.IndexByte(b[i:], '\n')
+		if n < 0 {
+			n = len(b) - i
+		}
+		if strings.TrimSpace(string(b[i:i+n])) == f {
+			if i+n < len(b) {
+				n++
+			}
+			return b[:i], b[i+n:], true
+		}
+		i += n + 1
+	}
+	return nil, all, false
+}
+
+// This reports whether the lines between yaml fences open with a key and
+// every other top level line is a key, ignoring blank lines, comments, list
+// items and indented lines, so that a deck of slides opening with a horizontal rule is
+// not mistaken for front matter, even when its first heading reads as a
+// comment and a slide holds a line that looks like a key.
+func keyed(b []byte) bool {
+	for i, l := range strings.Split(string(b), "\n") {
+		l = strings.TrimRight(l, "\r")
+		if i > 0 && (strings.TrimSpace(l) == "" || l[0] == ' ' || l[0] == '\t' || l[0] == '#' || strings.HasPrefix(l, "- ")) {
+			continue
+		}
+		if !pair.MatchString(l) {
+			return false
+		}
+	}
+	return true
+}
+
+// This converts json numbers into an int when possible, and a float64 when
+// not, so that they match the values produced by the other parsers.
+func number(v interface{}) interface{} {
+	switch n := v.(type) {
+	case json.Number:
+		if i, e := n.Int64(); e == nil {
+			return int(i)
+		}
+		f, _ := n.Float64()
+		return f
+	case map[string]interface{}:
+		for k := range n {
+			n[k] = number(n[k])
+		}
+	case []interface{}:
+		for i := range n {
+			n[i] = number(n[i])
+		}
+	}
+	return v
+}
+
+// This parses a single scalar or inline list, shared by the yaml and toml
+// parsers.
+func value(s string) interface{} {
+	s = strings.TrimSpace(s)
+	switch {
+	case len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"':
+		if u, e := strconv.Unquote(s); e == nil {
+			return u
+		}
+		return s[1 : len(s)-1]
+	case len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'':
+		return strings.Replace(s[1:len(s)-1], "''", "'", -1)
+	case len(s) >= 2 && s[0] == '[' && s[len(s)-1] == ']':
+		l := []interface{}{}
+		for _, v := range split(s[1 : len(s)-1]) {
+			if strings.TrimSpace(v) != "" {
+				l = append(l, value(v))
+			}
+		}
+		return l
+	case s == "true":
+		return true
+	case s == "false":
+		return false
+	}
+	if i, e := strconv.Atoi(s); e == nil {
+		return i
+	}
+	if f, e := strconv.ParseFloat(s, 64); e == nil {
+		return f
+	}
+	return s
+}
+
+// This splits an inline list on commas that are not quoted.
+func split(s string) []string {
+	var l []string
+	var q rune
+	var last int
+	for i, r := range s {
+		switch {
+		case q != 0 && r == q:
+			q = 0
+		case q == 0 && (r == '"' || r == '\''):
+			q = r
+		case q == 0 && r == ',':
+			l = append(l, s[last:i])
+			last = i + 1
+		}
+	}
+	return append(l, s[last:])
+}
+
+// This removes a trailing comment that is not quoted.
+func uncomment(s string) string {
+	var q rune
+	for i, r := range s {
+		switch {
+		case q != 0 && r == q:
+			q = 0
+		case q == 0 && (r == '"' || r == '\''):
+			q = r
+		case q == 0 && r == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return strings.TrimSpace(s[:i])
+		}
+	}
+	return strings.TrimSpace(s)
+}
+
+// A minimal yaml parser, which accepts `key: value` pairs, lists written
+// inline or as `- item` lines beneath a key, indented or not, and maps
+// indented beneath a key.
+func yaml(b []byte, params map[string]interface{}) error {
+	var key string
+	var nested map[string]interface{}
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; s.Scan(); n++ {
+		line := uncomment(s.Text())
+		indented := strings.HasPrefix(s.Text(), " ") || strings.HasPrefix(s.Text(), "\t")
+		if line == "" {
+			continue
+		}
+		if l, ok := params[key].([]interface{}); strings.HasPrefix(line, "- ") && (ok || nested != nil && len(nested) == 0) {
+			params[key], nested = append(l, value(line[2:])), nil
+			continue
+		}
+		i := strings.Index(line, ":")
+		if i < 0 {
+			return fmt.Errorf("invalid front matter on line %d: %s", n, line)
+		}
+		k, v := strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+		if indented && nested != nil {
+			nested[k] = value(v)
+			continue
+		}
+		key, nested = k, nil
+		if v != "" {
+			params[k] = value(v)
+			continue
+		}
+		nested = make(map[string]interface{})
+		params[k] = nested
+	}
+	for k, v := range params {
+		if m, ok := v.(map[string]interface{}); ok && len(m) == 0 {
+			params[k] = nil
+		}
+	}
+	return s.Err()
+}
+
+// This counts the square brackets that are not quoted, returning how many
+// more were opened than closed.
+func brackets(s string) int {
+	var d int
+	var q rune
+	for _, r := range s {
+		switch {
+		case q != 0 && r == q:
+			q = 0
+		case q == 0 && (r == '"' || r == '\''):
+			q = r
+		case q == 0 && r == '[':
+			d++
+		case q == 0 && r == ']':
+			d--
+		}
+	}
+	return d
+}
+
+// A minimal toml parser, which accepts `key = value` pairs, arrays on one or
+// more lines, and `[table]` headers for a single level of nesting.
+func toml(b []byte, params map[string]interface{}) error {
+	table := params
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; s.Scan(); n++ {
+		line := uncomment(s.Text())
+		if line == "" {
+			continue
+		}
+		for i := strings.Index(line, "="); i > 0 && brackets(line[i+1:]) > 0 && s.Scan(); n++ {
+			line += " " + uncomment(s.Text())
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			table = make(map[string]interface{})
+			params[strings.TrimSpace(line[1:len(line)-1])] = table
+			continue
+		}
+		i := strings.Index(line, "=")
+		if i < 0 {
+			return fmt.Errorf("invalid front matter on line %d: %s", n, line)
+		}
+		table[strings.Trim(strings.TrimSpace(line[:i]), `"`)] = value(line[i+1:])
+	}
+	return s.Err()
+}
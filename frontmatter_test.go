@@ -0,0 +1,54 @@
+package static
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFrontmatter(t *testing.T) {
+	for _, c := range []struct {
+		name, in string
+	}{
+		{"yaml", "---\ntitle: \"Hello: World\"\ndate: 2017-04-13\nweight: 2\ndraft: true\ntags: [a, 'b']\nparams:\n  color: blue # comment\n---\n\n# body"},
+		{"yaml sequence", "---\ntitle: \"Hello: World\"\ndate: 2017-04-13\nweight: 2\ndraft: true\ntags:\n- a\n- 'b'\nparams:\n  color: blue\n---\n\n# body"},
+		{"toml", "+++\ntitle = \"Hello: World\"\ndate = 2017-04-13\nweight = 2\ndraft = true\ntags = [\"a\", \"b\"]\n[params]\ncolor = \"blue\"\n+++\n# body"},
+		{"toml array", "+++\ntitle = \"Hello: World\"\ndate = 2017-04-13\nweight = 2\ndraft = true\ntags = [\n  \"a\", # first\n  \"b\",\n]\n[params]\ncolor = \"blue\"\n+++\n# body"},
+		{"json", "{\"title\": \"Hello: World\", \"date\": \"2017-04-13\", \"weight\": 2, \"draft\": true, \"tags\": [\"a\", \"b\"], \"params\": {\"color\": \"blue\"}}\n# body"},
+	} {
+		p, b, e := frontmatter([]byte(c.in))
+		if e != nil {
+			t.Errorf("%s: %v", c.name, e)
+			continue
+		}
+		if string(b) != "# body" {
+			t.Errorf("%s: unexpected body %q", c.name, b)
+		}
+		if p.Title != "Hello: World" || p.Weight != 2 || !p.Draft || !p.Date.Equal(time.Date(2017, 4, 13, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("%s: unexpected page %#v", c.name, p)
+		}
+		if l, _ := p.Params["tags"].([]interface{}); len(l) != 2 || l[1] != "b" {
+			t.Errorf("%s: unexpected tags %#v", c.name, p.Params["tags"])
+		}
+		if n, _ := p.Params["params"].(map[string]interface{}); n["color"] != "blue" {
+			t.Errorf("%s: unexpected params %#v", c.name, p.Params["params"])
+		}
+	}
+
+	// dates may include a time with or without seconds and a zone
+	for _, d := range []string{"2024-01-02 10:00", "2024-01-02T10:00", "2024-01-02 10:00:00", "2024-01-02 10:00:00 +0000", "2024-01-02T10:00:00Z"} {
+		if p, _, e := frontmatter([]byte("---\ndate: " + d + "\n---\n")); e != nil || !p.Date.Equal(time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)) {
+			t.Errorf("unexpected date from %s: %v %v", d, p.Date, e)
+		}
+	}
+
+	// files without front matter, an unterminated rule, rules around anything
+	// but keys, or a brace that does not open a json object are untouched
+	for _, in := range []string{"# body", "---\n# body", "{placeholder} is replaced by the tool", "---\n# Intro\n\n---\n\n# Second\n", "---\n# Welcome\n\nNote: greet everyone\n\n---\n\n# Second\n", "---\ntitle: valid\nnot valid\n---\n"} {
+		if _, b, e := frontmatter([]byte(in)); e != nil || string(b) != in {
+			t.Errorf("expected %q unchanged, got %q: %v", in, b, e)
+		}
+	}
+	if _, _, e := frontmatter([]byte("---\ntitle: valid\n  not valid\n---\n")); e == nil {
+		t.Error("expected invalid front matter to fail")
+	}
+}
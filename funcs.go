@@ -0,0 +1,138 @@
+package static
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var getenv = os.Getenv
+
+// This builds the functions available to every template, which are bound to
+// the page at the current path so that relative urls work from any location.
+//
+//	relURL "css/site.css"          url relative to the current page
+//	absURL "css/site.css"          url prefixed with the BaseURL
+//	dateFormat "Jan 2, 2006" .Date format a time or a front matter date
+//	slugify "A Title"              the anchor github would use for the text
+//	markdownify "*inline*"         markdown rendered with the Renderer
+//	plainify .Content              text with every html tag removed
+//	truncate 80 .Description       text cut at a word to at most 80 characters
+//	default "none" .Value          the value, or the default when it is empty
+//	dict "key" "value" ...         a map from alternating keys and values
+//	slice "a" "b" ...              a list of the supplied values
+//	readFile "path"                a file relative to the Input
+//	getenv "NAME"                  an environment variable
+//
+// Paths passed to relURL and absURL are relative to the root of the output,
+// and are left alone when they are already absolute urls.  The slice function
+// replaces the builtin of the same name.
+func (m *Markdown) funcs(r Renderer, current string) template.FuncMap {
+	return template.FuncMap{
+		"relURL": func(p string) string {
+			if strings.Contains(p, "://") || strings.HasPrefix(p, "//") {
+				return p
+			}
+			return relative(current, strings.TrimPrefix(p, "/"))
+		},
+		"absURL": func(p string) string {
+			if strings.Contains(p, "://") || strings.HasPrefix(p, "//") {
+				return p
+			}
+			return m.absolute(strings.TrimPrefix(p, "/"))
+		},
+		"dateFormat":  dateFormat,
+		"slugify":     slug,
+		"markdownify": func(s string) (template.HTML, error) { return markdownify(m.context(), r, s) },
+		"plainify": func(v interface{}) string {
+			return strings.TrimSpace(html.UnescapeString(tags.ReplaceAllString(fmt.Sprint(v), "")))
+		},
+		"truncate": truncate,
+		"default": func(d, v interface{}) interface{} {
+			if t, _ := template.IsTrue(v); t {
+				return v
+			}
+			return d
+		},
+		"dict": func(kv ...interface{}) (map[string]interface{}, error) {
+			if len(kv)%2 != 0 {
+				return nil, errors.New("dict requires pairs of keys and values")
+			}
+			d := make(map[string]interface{}, len(kv)/2)
+			for i := 0; i < len(kv); i += 2 {
+				k, ok := kv[i].(string)
+				if !ok {
+					return nil, fmt.Errorf("dict keys must be strings: %v", kv[i])
+				}
+				d[k] = kv[i+1]
+			}
+			return d, nil
+		},
+		"slice":    func(v ...interface{}) []interface{} { return v },
+		"readFile": m.readFile,
+		"getenv":   getenv,
+	}
+}
+
+// This formats a time, or a string in any of the formats accepted by front
+// matter, with the supplied layout.
+func dateFormat(layout string, v interface{}) (string, error) {
+	switch d := v.(type) {
+	case time.Time:
+		return d.Format(layout), nil
+	case string:
+		for i := range dates {
+			if t, e := time.Parse(dates[i], d); e == nil {
+				return t.Format(layout), nil
+			}
+		}
+		return "", fmt.Errorf("unrecognized date: %s", d)
+	}
+	return "", fmt.Errorf("unrecognized date: %v", v)
+}
+
+// This renders a string of markdown, removing the paragraph wrapped around a
+// single line so that it can be used inline.
+func markdownify(ctx context.Context, r Renderer, s string) (template.HTML, error) {
+	res, e := r.Render(ctx, Source{Content: []byte(s)})
+	if e != nil {
+		return "", e
+	}
+	b := bytes.TrimSpace(res.HTML)
+	if bytes.HasPrefix(b, []byte("<p>")) && bytes.HasSuffix(b, []byte("</p>")) && bytes.Count(b, []byte("<p>")) == 1 {
+		b = b[3 : len(b)-4]
+	}
+	return template.HTML(b), nil
+}
+
+// This cuts text to at most n characters, at the last space when there is one,
+// and ends it with an ellipsis.
+func truncate(n int, s string) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)[:n]
+	if i := strings.LastIndex(string(r), " "); i > 0 {
+		return strings.TrimRight(string(r)[:i], " ,.;:") + "…"
+	}
+	return string(r) + "…"
+}
+
+// This reads a file relative to the Input, which cannot escape it.
+func (m *Markdown) readFile(p string) (string, error) {
+	in, e := open(filepath.Join(m.Input, filepath.Clean("/"+p)))
+	if e != nil {
+		return "", e
+	}
+	defer in.Close()
+	b, e := readall(in)
+	return string(b), e
+}
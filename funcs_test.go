@@ -0,0 +1,50 @@
+package static
+
+import (
+	"bytes"
+	"html/template"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestMarkdownFuncs(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{
+		"snippet.txt": "snippet",
+	})
+	defer cleanup()
+	getenv = func(string) string { return "env" }
+
+	m := &Markdown{Input: d, BaseURL: "https://example.com/"}
+	r := Operation(func(b []byte) []byte { return append([]byte("<p>"), append(b, "</p>"...)...) })
+	cases := map[string]string{
+		`{{relURL "css/site.css"}} {{relURL "/"}} {{relURL "https://cdn.com/a.js"}}`: `../css/site.css .. https://cdn.com/a.js`,
+		`{{absURL "/a b.html"}}`: `https://example.com/a%20b.html`,
+		`{{dateFormat "Jan 2, 2006" "2017-03-01"}} {{dateFormat "2006" .Date}}`: `Mar 1, 2017 2018`,
+		`{{slugify "Hello, World"}}`:                                    `hello-world`,
+		`{{markdownify "*a*"}}`:                                         `*a*`,
+		`{{plainify "<p>a &amp; <b>b</b></p>"}}`:                        `a &amp; b`,
+		`{{truncate 8 "some long text"}} {{truncate 20 "short"}}`:       `some… short`,
+		`{{.Empty | default "none"}} {{"set" | default "none"}}`:        `none set`,
+		`{{$d := dict "a" 1 "b" (slice 2 3)}}{{$d.a}} {{index $d.b 1}}`: `1 3`,
+		`{{readFile "../snippet.txt"}} {{getenv "HOME"}}`:               `snippet env`,
+	}
+	for in, out := range cases {
+		tmpl, e := template.New("test").Funcs(m.funcs(r, "blog/post.html")).Parse(in)
+		if e != nil {
+			t.Fatal(e)
+		}
+		var b bytes.Buffer
+		if e := tmpl.Execute(&b, map[string]interface{}{"Date": time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), "Empty": ""}); e != nil {
+			t.Errorf("%s: %v", in, e)
+		} else if b.String() != out {
+			t.Errorf("%s: expected %q, got %q", in, out, b.String())
+		}
+	}
+
+	// invalid arguments fail the template
+	tmpl := template.Must(template.New("test").Funcs(m.funcs(r, "")).Parse(`{{dict "a"}}`))
+	if e := tmpl.Execute(ioutil.Discard, nil); e == nil {
+		t.Error("expected dict with an odd number of arguments to fail")
+	}
+}
@@ -0,0 +1,288 @@
+package static
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var codeblocks = regexp.MustCompile(`(?is)(<pre[^>]*>\s*<code[^>]*\bclass="[^"]*\blang(?:uage)?-([\w+#-]+)[^"]*"[^>]*>)(.*?)(</code>)`)
+
+// The rules used to tokenize a single language.
+//
+// Keys are identifiers or strings immediately followed by a colon, which are
+// only highlighted in data formats, and Variables start with a dollar sign.
+type language struct {
+	keywords  map[string]bool
+	types     map[string]bool
+	comments  []string
+	blocks    [][2]string
+	quotes    string
+	triple    bool
+	fold      bool
+	keys      bool
+	variables bool
+	diff      bool
+}
+
+// This builds a set from a space separated list of words.
+func words(s string) map[string]bool {
+	w := make(map[string]bool)
+	for _, k := range strings.Fields(s) {
+		w[k] = true
+	}
+	return w
+}
+
+var golang = &language{
+	keywords: words("break case chan const continue default defer else fallthrough for func go goto if import interface map package range return select struct switch type var true false nil iota"),
+	types:    words("bool byte complex64 complex128 error float32 float64 int int8 int16 int32 int64 rune string uint uint8 uint16 uint32 uint64 uintptr append cap close copy delete len make new panic print println recover"),
+	comments: []string{"//"},
+	blocks:   [][2]string{{"/*", "*/"}},
+	quotes:   "\"'`",
+}
+
+var shell = &language{
+	keywords:  words("if then else elif fi for in do done case esac while until function return local export readonly set unset shift exit break continue source alias echo cd test true false"),
+	comments:  []string{"#"},
+	quotes:    "\"'",
+	variables: true,
+}
+
+var javascript = &language{
+	keywords: words("async await break case catch class const continue debugger default delete do else export extends finally for from function if import in instanceof let new of return static super switch this throw try typeof var void while with yield true false null undefined"),
+	types:    words("Array Boolean Date Error JSON Map Math Number Object Promise RegExp Set String Symbol console document window"),
+	comments: []string{"//"},
+	blocks:   [][2]string{{"/*", "*/"}},
+	quotes:   "\"'`",
+}
+
+var python = &language{
+	keywords: words("and as assert async await break class continue def del elif else except finally for from global if import in is lambda nonlocal not or pass raise return try while with yield True False None"),
+	types:    words("bool bytes dict float int list object set str tuple len print range open self super isinstance enumerate zip"),
+	comments: []string{"#"},
+	quotes:   "\"'",
+	triple:   true,
+}
+
+var languages = map[string]*language{
+	"go":         golang,
+	"golang":     golang,
+	"sh":         shell,
+	"bash":       shell,
+	"shell":      shell,
+	"zsh":        shell,
+	"console":    shell,
+	"js":         javascript,
+	"javascript": javascript,
+	"py":         python,
+	"python":     python,
+	"json": {
+		keywords: words("true false null"),
+		quotes:   "\"",
+		keys:     true,
+	},
+	"yaml": {
+		keywords: words("true false null yes no on off"),
+		comments: []string{"#"},
+		quotes:   "\"'",
+		keys:     true,
+	},
+	"yml": {
+		keywords: words("true false null yes no on off"),
+		comments: []string{"#"},
+		quotes:   "\"'",
+		keys:     true,
+	},
+	"sql": {
+		keywords: words("add all alter and as asc begin between by case check column commit constraint create database default delete desc distinct drop else end exists foreign from full group having if in index inner insert into is join key left like limit not null offset on or order outer primary references right rollback select set table then union unique update values view when where with"),
+		types:    words("bigint blob boolean char date decimal float int integer numeric real serial smallint text timestamp varchar count sum avg min max coalesce"),
+		comments: []string{"--"},
+		blocks:   [][2]string{{"/*", "*/"}},
+		quotes:   "'\"",
+		fold:     true,
+	},
+	"diff":  {diff: true},
+	"patch": {diff: true},
+}
+
+// This wraps the text in a span with the supplied class, escaping it.
+func span(b *strings.Builder, class, s string) {
+	if class == "" {
+		b.WriteString(html.EscapeString(s))
+		return
+	}
+	b.WriteString(`<span class="hl-` + class + `">` + html.EscapeString(s) + `</span>`)
+}
+
+// This is true for the characters allowed in an identifier.
+func word(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// This finds the end of the string starting at i, honouring backslash escapes
+// except in go raw strings.  Strings that are not triple quoted or raw end at
+// the end of the line.
+func (l *language) quoted(s string, i int) int {
+	q := s[i : i+1]
+	if l.triple && strings.HasPrefix(s[i:], q+q+q) {
+		if n := strings.Index(s[i+3:], q+q+q); n >= 0 {
+			return i + 3 + n + 3
+		}
+		return len(s)
+	}
+	for j := i + 1; j < len(s); j++ {
+		switch {
+		case s[j] == '\\' && q != "`":
+			j++
+		case s[j] == q[0]:
+			return j + 1
+		case s[j] == '\n' && q != "`":
+			return j
+		}
+	}
+	return len(s)
+}
+
+// This is true when the next character after any spaces is a colon.
+func colon(s string) bool {
+	s = strings.TrimLeft(s, " \t")
+	return strings.HasPrefix(s, ":")
+}
+
+// This is true when only indentation or a list marker precedes i on its line,
+// so that values such as urls are not mistaken for keys.
+func leading(s string, i int) bool {
+	return strings.Trim(s[strings.LastIndexByte(s[:i], '\n')+1:i], " \t-") == ""
+}
+
+// This tokenizes code, wrapping keywords, types, strings, numbers, comments
+// and keys in spans.  Anything unrecognized is escaped but left as it was.
+func (l *language) highlight(s string) string {
+	var b strings.Builder
+	if l.diff {
+		for _, line := range strings.SplitAfter(s, "\n") {
+			switch {
+			case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"), strings.HasPrefix(line, "@@"), strings.HasPrefix(line, "diff "):
+				span(&b, "m", line)
+			case strings.HasPrefix(line, "+"):
+				span(&b, "i", line)
+			case strings.HasPrefix(line, "-"):
+				span(&b, "d", line)
+			default:
+				span(&b, "", line)
+			}
+		}
+		return b.String()
+	}
+	var plain int
+	flush := func(i int) {
+		span(&b, "", s[plain:i])
+	}
+	emit := func(i, j int, class string) int {
+		flush(i)
+		span(&b, class, s[i:j])
+		plain = j
+		return j
+	}
+	for i := 0; i < len(s); {
+		c := s[i]
+		after := i == 0 || !word(s[i-1])
+		if n := l.comment(s, i); n > i {
+			i = emit(i, n, "c")
+			continue
+		}
+		switch {
+		case strings.IndexByte(l.quotes, c) >= 0:
+			j := l.quoted(s, i)
+			if l.keys && colon(s[j:]) {
+				i = emit(i, j, "a")
+			} else {
+				i = emit(i, j, "s")
+			}
+		case l.variables && c == '$' && i+1 < len(s) && s[i+1] == '{':
+			j := strings.IndexByte(s[i:], '}')
+			if j < 0 {
+				j = len(s) - i - 1
+			}
+			i = emit(i, i+j+1, "v")
+		case l.variables && c == '$' && i+1 < len(s) && (word(s[i+1]) || strings.IndexByte("@#?*!$", s[i+1]) >= 0):
+			j := i + 2
+			for word(s[i+1]) && j < len(s) && word(s[j]) {
+				j++
+			}
+			i = emit(i, j, "v")
+		case after && c >= '0' && c <= '9':
+			j := i
+			for j < len(s) && (word(s[j]) || s[j] == '.') {
+				j++
+			}
+			i = emit(i, j, "n")
+		case after && word(c):
+			j := i
+			for j < len(s) && (word(s[j]) || (l.keys && s[j] == '-')) {
+				j++
+			}
+			w := s[i:j]
+			if l.fold {
+				w = strings.ToLower(w)
+			}
+			switch {
+			case l.keys && colon(s[j:]) && leading(s, i):
+				i = emit(i, j, "a")
+			case l.keywords[w]:
+				i = emit(i, j, "k")
+			case l.types[w]:
+				i = emit(i, j, "t")
+			default:
+				i = j
+			}
+		default:
+			i++
+		}
+	}
+	flush(len(s))
+	return b.String()
+}
+
+// This returns the end of a comment starting at i, or i when there is none.
+//
+// Comments starting with a hash must follow whitespace, so that anchors in
+// urls and variables such as `$#` are not mistaken for comments.
+func (l *language) comment(s string, i int) int {
+	for _, c := range l.comments {
+		if !strings.HasPrefix(s[i:], c) || (c == "#" && i > 0 && s[i-1] != ' ' && s[i-1] != '\t' && s[i-1] != '\n') {
+			continue
+		}
+		if n := strings.IndexByte(s[i:], '\n'); n >= 0 {
+			return i + n
+		}
+		return len(s)
+	}
+	for _, c := range l.blocks {
+		if !strings.HasPrefix(s[i:], c[0]) {
+			continue
+		}
+		if n := strings.Index(s[i+len(c[0]):], c[1]); n >= 0 {
+			return i + len(c[0]) + n + len(c[1])
+		}
+		return len(s)
+	}
+	return i
+}
+
+// This highlights every fenced code block with a recognized language, leaving
+// the rest of the html untouched.
+//
+// The code is unescaped before it is tokenized, and every token is escaped
+// again as it is wrapped, so the code reads the same apart from the spans.
+func highlight(b []byte) []byte {
+	return codeblocks.ReplaceAllFunc(b, func(c []byte) []byte {
+		s := codeblocks.FindSubmatch(c)
+		l, ok := languages[strings.ToLower(string(s[2]))]
+		if !ok {
+			return c
+		}
+		return []byte(string(s[1]) + l.highlight(html.UnescapeString(string(s[3]))) + string(s[4]))
+	})
+}
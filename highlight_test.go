@@ -0,0 +1,33 @@
+package static
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	cases := map[string]string{
+		`<pre><code class="language-go">func main() { // run
+	s := &quot;a \&quot; b&quot; + 42
+}</code></pre>`: `<pre><code class="language-go"><span class="hl-k">func</span> main() { <span class="hl-c">// run</span>
+	s := <span class="hl-s">&#34;a \&#34; b&#34;</span> + <span class="hl-n">42</span>
+}</code></pre>`,
+		`<pre><code class="language-bash">echo ${HOME}/a#b # done</code></pre>`: `<pre><code class="language-bash"><span class="hl-k">echo</span> <span class="hl-v">${HOME}</span>/a#b <span class="hl-c"># done</span></code></pre>`,
+		`<pre><code class="language-yaml">- url: http://example.com
+  draft: true</code></pre>`: `<pre><code class="language-yaml">- <span class="hl-a">url</span>: http://example.com
+  <span class="hl-a">draft</span>: <span class="hl-k">true</span></code></pre>`,
+		`<pre><code class="language-json">{&quot;a&quot;: null}</code></pre>`: `<pre><code class="language-json">{<span class="hl-a">&#34;a&#34;</span>: <span class="hl-k">null</span>}</code></pre>`,
+		`<pre><code class="language-sql">Select count(*) FROM t</code></pre>`: `<pre><code class="language-sql"><span class="hl-k">Select</span> <span class="hl-t">count</span>(*) <span class="hl-k">FROM</span> t</code></pre>`,
+		`<pre><code class="language-python">x = """a
+b"""</code></pre>`: `<pre><code class="language-python">x = <span class="hl-s">&#34;&#34;&#34;a
+b&#34;&#34;&#34;</span></code></pre>`,
+		"<pre><code class=\"language-diff\">@@ -1 +1 @@\n-a\n+b\n</code></pre>": "<pre><code class=\"language-diff\"><span class=\"hl-m\">@@ -1 +1 @@\n</span><span class=\"hl-d\">-a\n</span><span class=\"hl-i\">+b\n</span></code></pre>",
+		`<pre><code class="language-cobol">MOVE A TO B</code></pre>`:            `<pre><code class="language-cobol">MOVE A TO B</code></pre>`,
+		`<p><code>func</code></p>`:                                              `<p><code>func</code></p>`,
+	}
+	for in, out := range cases {
+		if h := string(highlight([]byte(in))); h != out {
+			t.Errorf("unexpected highlight of %s:\n%s", strings.SplitN(in, "\n", 2)[0], h)
+		}
+	}
+}
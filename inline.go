@@ -0,0 +1,94 @@
+package static
+
+import (
+	"encoding/base64"
+	"html"
+	"mime"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var sources = regexp.MustCompile(`(?i)(<(img|link)\s[^>]*?(?:src|href)\s*=\s*")([^"]*)("[^>]*>)`)
+var rels = regexp.MustCompile(`(?i)\srel\s*=\s*"([^"]*)"`)
+
+// The relationships of the links that are inlined, since other links such as
+// `canonical` or `next` point at pages rather than assets.
+var inlined = []string{"stylesheet", "icon", "apple-touch-icon"}
+
+// Assets larger than this many bytes are still inlined, but produce a warning
+// since they can make the output unwieldy.
+var oversized = 1 << 20
+
+// This reads a local file into a data uri, using the extension to find the
+// mime type, and the contents when the extension is unknown.
+func (m *Markdown) datauri(file string) (string, error) {
+	in, e := open(file)
+	if e != nil {
+		return "", e
+	}
+	b, e := readall(in)
+	m.errors(in.Close())
+	if e != nil {
+		return "", e
+	}
+	if len(b) > oversized {
+		m.L.Info("Inlining oversized asset %s (%d bytes)", file, len(b))
+	}
+	t := mime.TypeByExtension(filepath.Ext(file))
+	if t == "" {
+		t = http.DetectContentType(b)
+	}
+	return "data:" + strings.Replace(t, " ", "", -1) + ";base64," + base64.StdEncoding.EncodeToString(b), nil
+}
+
+// This reports whether a link tag points at an asset by its relationship.
+func linked(tag string) bool {
+	m := rels.FindStringSubmatch(tag)
+	if m == nil {
+		return false
+	}
+	for _, r := range strings.Fields(strings.ToLower(m[1])) {
+		for i := range inlined {
+			if r == inlined[i] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// This replaces the source of every local image, and of every local
+// stylesheet or icon, with a data uri, so that the html can be moved without
+// breaking them.
+//
+// Relative paths are resolved against the supplied directory, and absolute
+// urls are left alone.  Images that cannot be read are reported, while links
+// that do not point at a file are ignored since not every link is an asset.
+func (m *Markdown) inline(dir string, b []byte) []byte {
+	return sources.ReplaceAllFunc(b, func(a []byte) []byte {
+		s := sources.FindSubmatch(a)
+		src := html.UnescapeString(string(s[3]))
+		p, _ := target(src)
+		u, e := url.PathUnescape(p)
+		if p == "" || e != nil {
+			return a
+		}
+		link := strings.EqualFold(string(s[2]), "link")
+		if link && !linked(string(a)) {
+			return a
+		}
+		file := filepath.Join(dir, filepath.FromSlash(u))
+		if f, e := stat(file); link && (e != nil || f.IsDir()) {
+			return a
+		}
+		d, e := m.datauri(file)
+		if e != nil {
+			m.L.Info("Unable to inline %s: %v", src, e)
+			return a
+		}
+		return []byte(string(s[1]) + d + string(s[4]))
+	})
+}
@@ -0,0 +1,42 @@
+package static
+
+import (
+	"strings"
+	"testing"
+)
+
+type countLogger struct {
+	mockLogger
+	info int
+}
+
+func (l *countLogger) Info(string, ...interface{}) { l.info++ }
+
+func TestInline(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{
+		"img/dot.png": "\x89PNG\r\n\x1a\n",
+		"style.css":   "body{}",
+	})
+	defer cleanup()
+
+	// local assets become data uris, while urls, missing files and links to
+	// anything other than stylesheets and icons are untouched
+	l := &countLogger{}
+	m := &Markdown{L: l}
+	b := string(m.inline(d, []byte(`<img alt="x" src="img/dot.png"><link rel="stylesheet" href="style.css"><img src="https://example.com/a.png"><link rel="canonical" href="page"><img src="missing.png"><link href="img/dot.png" rel="shortcut icon"><link rel="next" href="style.css">`)))
+	for _, s := range []string{`<img alt="x" src="data:image/png;base64,iVBORw0KGgo=">`, `href="data:text/css;charset=utf-8;base64,Ym9keXt9"`, `src="https://example.com/a.png"`, `href="page"`, `src="missing.png"`, `<link href="data:image/png;base64,iVBORw0KGgo=" rel="shortcut icon">`, `<link rel="next" href="style.css">`} {
+		if !strings.Contains(b, s) {
+			t.Errorf("expected %s in %s", s, b)
+		}
+	}
+	if l.info != 1 {
+		t.Errorf("expected a warning for the missing image, got %d", l.info)
+	}
+
+	// oversized assets produce a warning
+	oversized = 4
+	m.inline(d, []byte(`<img src="img/dot.png">`))
+	if l.info != 2 {
+		t.Errorf("expected a warning for the oversized image, got %d", l.info)
+	}
+}
@@ -0,0 +1,142 @@
+package static
+
+import (
+	"fmt"
+	"html/template"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// The name of the template file that sets the layout for the pages in its
+// directory and every directory beneath it in web mode.
+const layoutFile = "_layout.tmpl"
+
+// The name of a template file within the set, which is the file name without
+// the extension, the directory relative to the input path followed by
+// `_layout` for directory layouts, or the current mode for the Template.
+func (m *Markdown) name(file string) string {
+	n := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	switch {
+	case file == m.Template:
+		return m.mode()
+	case filepath.Base(file) == layoutFile:
+		if d, e := filepath.Rel(m.Input, filepath.Dir(file)); e == nil {
+			return path.Join(filepath.ToSlash(d), n)
+		}
+	}
+	return n
+}
+
+// The name of the layout used by the current output mode.
+func (m *Markdown) mode() string {
+	if m.Web {
+		return "web"
+	} else if m.Slides {
+		return "slides"
+	}
+	return "book"
+}
+
+// The template files in use, which are every template in the TemplatesDir,
+// then the directory layouts found under the input path, and the Template.
+func (m *Markdown) templates() []string {
+	var l []string
+	if m.TemplatesDir != "" {
+		l, _ = filepath.Glob(filepath.Join(m.TemplatesDir, "*.tmpl"))
+	}
+	l = append(l, m.layouts...)
+	if m.Template != "" {
+		l = append(l, m.Template)
+	}
+	return l
+}
+
+// This reports whether the file is one of the templates, a directory layout,
+// or is inside the TemplatesDir.
+func (m *Markdown) istemplate(file string) bool {
+	if file == m.Template || filepath.Base(file) == layoutFile {
+		return true
+	}
+	return m.TemplatesDir != "" && strings.HasPrefix(file, filepath.Clean(m.TemplatesDir)+string(filepath.Separator))
+}
+
+// This reads every template file into a single set, on top of the embedded
+// template for the current mode, so that layouts may share partials and base
+// layouts through `{{define}}`, `{{block}}` and `{{template}}`.
+//
+// Each file in the TemplatesDir is named after its file name without the
+// extension, so `web.tmpl` replaces the embedded web template and `post.tmpl`
+// is available as the post layout.  The Template is always named after the
+// current mode, since it replaces the embedded template, and directory
+// layouts are named after their directory.
+//
+// The source of each file is kept, so that a layout can be parsed again when
+// it is used, and any blocks it defines take precedence over the same blocks
+// defined by other layouts.
+func (m *Markdown) parse(t *template.Template) error {
+	m.sources = make(map[string]string)
+	for _, file := range m.templates() {
+		in, e := open(file)
+		if e != nil {
+			return e
+		}
+		b, e := readall(in)
+		m.errors(in.Close())
+		if e != nil {
+			return e
+		}
+		name := m.name(file)
+		if _, e := t.New(name).Parse(string(b)); e != nil {
+			return fmt.Errorf("%s: %v", file, e)
+		}
+		m.sources[name] = string(b)
+	}
+	return nil
+}
+
+// This prepares the named layout for a page at the current path, by cloning
+// the set so that relURL is bound to the page, and parsing the layout again
+// so that its blocks take precedence.
+func (m *Markdown) layout(t *template.Template, name string, r Renderer, current string) (*template.Template, error) {
+	c, e := t.Clone()
+	if e != nil {
+		return nil, e
+	}
+	c.Funcs(m.funcs(r, current))
+	if s, ok := m.sources[name]; ok {
+		return c.New(name).Parse(s)
+	}
+	if l := c.Lookup(name); l != nil {
+		return l, nil
+	}
+	return nil, fmt.Errorf("unknown layout: %s", name)
+}
+
+// This chooses the layout for a page, which is the layout named by the front
+// matter, then the layout of the nearest directory containing the page, and
+// finally the layout of the current mode.
+//
+// Naming a layout that does not exist is an error, rather than silently
+// falling back, since the page would otherwise look correct but incomplete.
+func (m *Markdown) choose(t *template.Template, file string, p Page) (string, error) {
+	if p.Layout != "" {
+		if p.Layout == t.Name() || t.Lookup(p.Layout) == nil {
+			return "", fmt.Errorf("%s: unknown layout: %s", file, p.Layout)
+		}
+		return p.Layout, nil
+	}
+	for d := filepath.Dir(file); ; d = filepath.Dir(d) {
+		r, e := filepath.Rel(m.Input, d)
+		if e != nil || strings.HasPrefix(r, "..") {
+			break
+		}
+		if n := path.Join(filepath.ToSlash(r), "_layout"); m.sources[n] != "" {
+			return n, nil
+		}
+		if r == "." {
+			break
+		}
+	}
+	return m.mode(), nil
+}
@@ -0,0 +1,58 @@
+package static
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownLayouts(t *testing.T) {
+	d, cleanup := fixture(t, nil)
+	defer cleanup()
+	l := filepath.Join(d, "layouts")
+	os.Mkdir(l, os.ModePerm)
+	ioutil.WriteFile(filepath.Join(l, "base.tmpl"), []byte(`{{define "base"}}<html>{{block "main" .}}default{{end}}</html>{{end}}`), 0644)
+	ioutil.WriteFile(filepath.Join(l, "partial.tmpl"), []byte(`{{define "partial"}}shared{{end}}`), 0644)
+	ioutil.WriteFile(filepath.Join(l, "web.tmpl"), []byte(`{{template "base" .}}{{define "main"}}web {{.Content}}{{end}}`), 0644)
+	ioutil.WriteFile(filepath.Join(l, "post.tmpl"), []byte(`{{template "base" .}}{{define "main"}}post {{template "partial"}}{{end}}`), 0644)
+	ioutil.WriteFile(filepath.Join(d, "a.md"), []byte("a"), 0644)
+	ioutil.WriteFile(filepath.Join(d, "b.md"), []byte("---\nlayout: post\n---\nb"), 0644)
+	ioutil.WriteFile(filepath.Join(d, "c.md"), []byte("---\nlayout: missing\n---\nc"), 0644)
+	os.MkdirAll(filepath.Join(d, "docs", "api"), os.ModePerm)
+	ioutil.WriteFile(filepath.Join(d, "docs", layoutFile), []byte(`{{template "base" .}}{{define "main"}}docs {{.Content}}{{end}}`), 0644)
+	ioutil.WriteFile(filepath.Join(d, "docs", "api", "e.md"), []byte("e"), 0644)
+	ioutil.WriteFile(filepath.Join(d, "docs", "api", "f.md"), []byte("---\nlayout: post\n---\nf"), 0644)
+
+	// each page uses the layout from its front matter or nearest directory,
+	// sharing the base and partials, and unknown layouts fail
+	m := &Markdown{L: &mockLogger{}, Input: d, Web: true, TemplatesDir: l}
+	if e := m.Run(Operation(func(b []byte) []byte { return b })); e == nil || !strings.Contains(e.Error(), "c.md: unknown layout: missing") {
+		t.Errorf("expected an unknown layout, got %v", e)
+	}
+	for f, expect := range map[string]string{"a.html": "<html>web a</html>", "b.html": "<html>post shared</html>", "docs/api/e.html": "<html>docs e</html>", "docs/api/f.html": "<html>post shared</html>"} {
+		if b, _ := ioutil.ReadFile(filepath.Join(d, "public", filepath.FromSlash(f))); string(b) != expect {
+			t.Errorf("unexpected %s: %s", f, b)
+		}
+	}
+	if _, e := os.Stat(filepath.Join(d, "public", "c.html")); !os.IsNotExist(e) {
+		t.Error("expected no page with an unknown layout")
+	}
+
+	// templates are never copied
+	for _, f := range []string{"layouts", filepath.Join("docs", layoutFile)} {
+		if _, e := os.Stat(filepath.Join(d, "public", f)); !os.IsNotExist(e) {
+			t.Errorf("expected %s not to be copied", f)
+		}
+	}
+
+	// missing layouts fall back to the embedded templates
+	m = &Markdown{L: &mockLogger{}, Input: d, Output: filepath.Join(d, "book.html"), TemplatesDir: l}
+	if e := m.Run(Operation(func(b []byte) []byte { return b })); e != nil {
+		t.Fatal(e)
+	}
+	if b, _ := ioutil.ReadFile(m.Output); !strings.Contains(string(b), "<nav>") {
+		t.Errorf("expected the embedded book template, got %s", b)
+	}
+}
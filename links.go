@@ -0,0 +1,149 @@
+package static
+
+import (
+	"bufio"
+	"html"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var hrefs = regexp.MustCompile(`(?i)(<a\s[^>]*?href\s*=\s*")([^"]*)(")`)
+
+// This separates a relative link into the path and the remaining query and
+// fragment, returning an empty path for absolute urls, links within the same
+// page, and anything that cannot be parsed.
+func target(href string) (string, string) {
+	u, e := url.Parse(href)
+	if e != nil || u.Scheme != "" || u.Host != "" || u.Opaque != "" || strings.HasPrefix(href, "/") {
+		return "", ""
+	}
+	i := strings.IndexAny(href, "?#")
+	if i < 0 {
+		i = len(href)
+	}
+	return href[:i], href[i:]
+}
+
+// This rewrites relative links in the html from the supplied markdown file,
+// replacing the extension with html when the link points at another markdown
+// file that is being processed.
+//
+// Any query or fragment is preserved, and links to anything else are left
+// alone.
+func (m *Markdown) relink(file string, b []byte) []byte {
+	return hrefs.ReplaceAllFunc(b, func(a []byte) []byte {
+		s := hrefs.FindSubmatch(a)
+		p, rest := target(html.UnescapeString(string(s[2])))
+		if p == "" {
+			return a
+		}
+		u, e := url.PathUnescape(p)
+		if e != nil {
+			return a
+		}
+		t := filepath.Join(filepath.Dir(file), filepath.FromSlash(u))
+		if !m.valid(t) || !m.matches(t) {
+			return a
+		}
+		href := strings.TrimSuffix(p, path.Ext(p)) + ".html" + rest
+		return []byte(string(s[1]) + html.EscapeString(href) + string(s[3]))
+	})
+}
+
+// This rewrites links in the html from the supplied markdown file that point
+// at a heading in the same file, or at another markdown file in the book, into
+// placeholders naming the file and fragment.  The placeholders are delimited
+// by null bytes and resolved once every anchor is known.
+//
+// Links to markdown files that are not part of the book are left alone, and
+// reported as unresolved.
+func (m *Markdown) crosslink(file string, b []byte) []byte {
+	return hrefs.ReplaceAllFunc(b, func(a []byte) []byte {
+		s := hrefs.FindSubmatch(a)
+		href := html.UnescapeString(string(s[2]))
+		t, f := file, ""
+		if strings.HasPrefix(href, "#") {
+			f = href[1:]
+		} else {
+			p, rest := target(href)
+			u, e := url.PathUnescape(p)
+			if p == "" || e != nil {
+				return a
+			}
+			t = filepath.Join(filepath.Dir(file), filepath.FromSlash(u))
+			if !m.valid(t) {
+				return a
+			} else if !m.exists(t) {
+				m.L.Info("Unresolved link to %s in %s", href, file)
+				return a
+			}
+			if i := strings.Index(rest, "#"); i >= 0 {
+				f = rest[i+1:]
+			}
+		}
+		if u, e := url.PathUnescape(f); e == nil {
+			f = u
+		}
+		return []byte(string(s[1]) + "\x00" + t + "#" + f + "\x00" + string(s[3]))
+	})
+}
+
+// This finds the anchor for a placeholder, prefixed by the document holding
+// the chapter, falling back to the start of the chapter when the heading
+// cannot be found.
+func (a *anchors) lookup(token string, warn func(string, ...interface{})) string {
+	i := strings.LastIndex(token, "#")
+	file, f := token[:i], token[i+1:]
+	c, ok := a.chapters[file]
+	if !ok {
+		warn("Unresolved link to %s", file)
+		return "#"
+	} else if f == "" {
+		return c.doc + "#" + c.id
+	} else if id, ok := c.ids[f]; ok {
+		return c.doc + "#" + id
+	}
+	warn("Unresolved link to #%s in %s", f, file)
+	return c.doc + "#" + c.id
+}
+
+// This copies the html to the writer, replacing each placeholder with the
+// anchor it resolves to.
+//
+// The html is streamed in chunks, so that only a placeholder is ever held in
+// memory.
+func (a *anchors) resolve(w io.Writer, r io.Reader, warn func(string, ...interface{})) error {
+	br := bufio.NewReader(r)
+	var token []byte
+	var inside bool
+	for {
+		b, e := br.ReadSlice('\x00')
+		if e == nil {
+			b = b[:len(b)-1]
+		}
+		if inside {
+			token = append(token, b...)
+		} else if _, e := w.Write(b); e != nil {
+			return e
+		}
+		switch e {
+		case nil:
+			if inside {
+				if _, e := io.WriteString(w, html.EscapeString(a.lookup(string(token), warn))); e != nil {
+					return e
+				}
+				token = token[:0]
+			}
+			inside = !inside
+		case bufio.ErrBufferFull:
+		case io.EOF:
+			return nil
+		default:
+			return e
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package static
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestRelink(t *testing.T) {
+	m := &Markdown{Input: "/src", files: []string{"/src/index.md", "/src/guide/setup.mkd", "/src/guide/my file.md"}}
+	for in, out := range map[string]string{
+		`<a href="../guide/setup.mkd">`:                  `<a href="../guide/setup.html">`,
+		`<a title="x" href="../index.md#intro">`:         `<a title="x" href="../index.html#intro">`,
+		`<a href="setup.mkd?v=1&amp;b=2#top">`:           `<a href="setup.html?v=1&amp;b=2#top">`,
+		`<a href="my%20file.md">`:                        `<a href="my%20file.html">`,
+		`<a href="missing.md">`:                          `<a href="missing.md">`,
+		`<a href="https://example.com/guide/setup.mkd">`: `<a href="https://example.com/guide/setup.mkd">`,
+		`<a href="/guide/setup.mkd">`:                    `<a href="/guide/setup.mkd">`,
+		`<a href="#setup">`:                              `<a href="#setup">`,
+		`<img src="setup.mkd">`:                          `<img src="setup.mkd">`,
+	} {
+		if b := m.relink(filepath.FromSlash("/src/guide/setup.mkd"), []byte(in)); string(b) != out {
+			t.Errorf("expected %s from %s, got %s", out, in, b)
+		}
+	}
+}
+
+func TestCrosslink(t *testing.T) {
+	var warnings int
+	warn := func(string, ...interface{}) { warnings++ }
+	m := &Markdown{L: &mockLogger{}, Input: "/src", files: []string{"/src/a.md", "/src/b.md"}}
+	a := &anchors{}
+
+	// the same heading in both files is deduplicated in the book
+	var b bytes.Buffer
+	for _, f := range []string{"a", "b"} {
+		fmt.Fprintf(&b, `<a id="%s"></a>`, a.begin("/src/"+f+".md", f))
+		b.Write(a.anchor(m.crosslink("/src/"+f+".md", []byte(`<h2>Options</h2><a href="#options">self</a><a href="a.md">a</a><a href="b.md#options">b</a><a href="b.md#nope">bad</a><a href="c.md">missing</a>`))))
+	}
+	var out bytes.Buffer
+	if e := a.resolve(&out, &b, warn); e != nil {
+		t.Fatal(e)
+	}
+	section := `<h2 id="%s">Options</h2><a href="#%s">self</a><a href="#chapter-a">a</a><a href="#options-1">b</a><a href="#chapter-b">bad</a><a href="c.md">missing</a>`
+	expect := `<a id="chapter-a"></a>` + fmt.Sprintf(section, "options", "options") + `<a id="chapter-b"></a>` + fmt.Sprintf(section, "options-1", "options-1")
+	if out.String() != expect {
+		t.Errorf("unexpected html:\n%s\n%s", out.String(), expect)
+	}
+	if warnings != 2 {
+		t.Errorf("expected a warning for each unresolved heading, got %d", warnings)
+	}
+}
@@ -0,0 +1,266 @@
+package static
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var elements = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)([^>]*)>`)
+var attributes = regexp.MustCompile(`(?i)\s(href|alt)\s*=\s*"([^"]*)"`)
+var sections = regexp.MustCompile(`^[1-9][a-z]*$`)
+var whitespace = regexp.MustCompile(`\s+`)
+
+// A list being written, with the number of the next item when ordered.
+type list struct {
+	ordered bool
+	n       int
+}
+
+// This converts html into man(7) roff, keeping track of the current font,
+// the open lists, and whether we are inside preformatted text.
+type roff struct {
+	b     bytes.Buffer
+	fonts []string
+	lists []list
+	links []string
+	pre   bool
+	text  bool
+}
+
+// This starts a macro on a new line, removing any trailing spaces left by the
+// text before it.
+func (r *roff) macro(s string) {
+	r.b.Truncate(len(bytes.TrimRight(r.b.Bytes(), " ")))
+	if r.text {
+		r.b.WriteString("\n")
+		r.text = false
+	}
+	r.b.WriteString(s + "\n")
+}
+
+// This escapes text for roff, protecting backslashes, hyphens, and lines that
+// would otherwise be read as requests.  Outside of preformatted text any run
+// of whitespace becomes a single space, since roff fills lines itself.
+func (r *roff) write(s string) {
+	s = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+	if !r.pre {
+		s = whitespace.ReplaceAllString(s, " ")
+		if !r.text {
+			s = strings.TrimLeft(s, " ")
+		}
+		if s == "" {
+			return
+		}
+	}
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		if i > 0 {
+			r.b.WriteString("\n")
+			r.text = false
+		}
+		if !r.text && (strings.HasPrefix(l, ".") || strings.HasPrefix(l, "'")) {
+			l = `\&` + l
+		}
+		r.b.WriteString(l)
+		r.text = r.text || l != ""
+	}
+}
+
+// This switches to a font, or restores the previous font when closing.
+func (r *roff) font(f string, closing bool) {
+	if !closing {
+		r.fonts = append(r.fonts, f)
+	} else if len(r.fonts) > 0 {
+		r.fonts = r.fonts[:len(r.fonts)-1]
+	}
+	f = "R"
+	if len(r.fonts) > 0 {
+		f = r.fonts[len(r.fonts)-1]
+	}
+	r.b.WriteString(`\f` + f)
+}
+
+// This maps a single html element onto roff macros or font changes, ignoring
+// any element without an equivalent so that only its text remains.
+func (r *roff) element(closing bool, name, attrs string) {
+	attr := func(a string) string {
+		for _, m := range attributes.FindAllStringSubmatch(attrs, -1) {
+			if strings.EqualFold(m[1], a) {
+				return html.UnescapeString(m[2])
+			}
+		}
+		return ""
+	}
+	switch name = strings.ToLower(name); {
+	case (name == "h1" || name == "h2") && !closing:
+		r.macro(".SH")
+	case len(name) == 2 && name[0] == 'h' && name[1] >= '3' && name[1] <= '6' && !closing:
+		r.macro(".SS")
+	case name == "p" && !closing:
+		if len(r.lists) == 0 {
+			r.macro(".PP")
+		} else if r.text {
+			r.macro(".IP")
+		}
+	case name == "br":
+		r.macro(".br")
+	case name == "em" || name == "i":
+		r.font("I", closing)
+	case name == "strong" || name == "b" || (name == "code" && !r.pre):
+		r.font("B", closing)
+	case name == "pre":
+		if !closing {
+			r.macro(".PP")
+			r.macro(".RS 4")
+			r.macro(".nf")
+		} else {
+			r.macro(".fi")
+			r.macro(".RE")
+		}
+		r.pre = !closing
+	case name == "blockquote":
+		if !closing {
+			r.macro(".RS 4")
+		} else {
+			r.macro(".RE")
+		}
+	case name == "ul" || name == "ol":
+		if !closing {
+			if len(r.lists) > 0 {
+				r.macro(".RS 4")
+			}
+			r.lists = append(r.lists, list{ordered: name == "ol", n: 1})
+		} else if len(r.lists) > 0 {
+			r.lists = r.lists[:len(r.lists)-1]
+			if len(r.lists) > 0 {
+				r.macro(".RE")
+			}
+		}
+	case name == "li" && !closing && len(r.lists) > 0:
+		l := &r.lists[len(r.lists)-1]
+		if l.ordered {
+			r.macro(fmt.Sprintf(`.IP "%d." 4`, l.n))
+			l.n++
+		} else {
+			r.macro(`.IP \(bu 2`)
+		}
+	case name == "a":
+		if !closing {
+			r.links = append(r.links, attr("href"))
+		} else if len(r.links) > 0 {
+			href := r.links[len(r.links)-1]
+			r.links = r.links[:len(r.links)-1]
+			if p, _ := target(href); href != "" && !strings.HasPrefix(href, "#") && p == "" {
+				r.write(" <" + href + ">")
+			}
+		}
+	case name == "img" && !closing:
+		r.write(attr("alt"))
+	}
+}
+
+// This converts the html for a single page into the body of a man page.
+//
+// Headings become sections, paragraphs, lists and block quotes are indented
+// with the matching macros, and preformatted text is left unfilled.  Links to
+// absolute urls are followed by the url, since a terminal cannot follow them.
+func manual(b []byte) string {
+	r := &roff{}
+	s := string(b)
+	for s != "" {
+		m := elements.FindStringSubmatchIndex(s)
+		if m == nil {
+			r.write(html.UnescapeString(s))
+			break
+		}
+		r.write(html.UnescapeString(s[:m[0]]))
+		r.element(s[m[2]:m[3]] == "/", s[m[4]:m[5]], s[m[6]:m[7]])
+		s = s[m[1]:]
+	}
+	return strings.TrimRight(r.b.String(), " \n")
+}
+
+// This finds the name and section of a man page, where a file named like
+// `tool.8.md` is in section 8, and a section in the front matter takes
+// precedence.  Pages default to section 1.
+func manpage(file string, p Page) (string, string) {
+	n := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	s := "1"
+	if e := strings.TrimPrefix(filepath.Ext(n), "."); sections.MatchString(e) {
+		n, s = strings.TrimSuffix(n, "."+e), e
+	}
+	switch v := p.Params["section"].(type) {
+	case int:
+		s = strconv.Itoa(v)
+	case string:
+		if sections.MatchString(v) {
+			s = v
+		}
+	}
+	return n, s
+}
+
+// This quotes an argument to a macro.
+func quote(s string) string {
+	return `"` + strings.Replace(strings.Replace(s, `\`, `\e`, -1), `"`, `\(dq`, -1) + `"`
+}
+
+// This renders a single markdown file into a man page, named after the file
+// and its section, in the same relative directory of the output.
+//
+// The header holds the upper case name, the section, the date from the front
+// matter or the modified time of the file, and the Title and Version as the
+// source, with the Title as the name of the manual.
+func (m *Markdown) roff(r Renderer, file string) error {
+	n, s := manpage(file, m.meta(file))
+	out := filepath.Join(m.Output, filepath.Dir(within(m.Input, file)), n+"."+s)
+	if !m.stale(out, file) {
+		m.L.Debug("Skipping unmodified file: %s", file)
+		return nil
+	}
+	in, e := open(file)
+	if e != nil {
+		return e
+	}
+	b, e := readall(in)
+	m.errors(in.Close())
+	if e != nil {
+		return e
+	}
+	p, d, e := m.render(r, file, b)
+	if e != nil {
+		return e
+	}
+	date := p.Date
+	if f, e := stat(file); e == nil && date.IsZero() {
+		date = f.ModTime()
+	}
+	source := strings.TrimSpace(m.Title + " " + m.Version)
+	if e := mkdirall(filepath.Dir(out), os.ModePerm); e != nil {
+		return e
+	}
+	f, e := create(out)
+	if e != nil {
+		return e
+	}
+	_, e = fmt.Fprintf(f, ".TH %s %s %s %s %s\n%s\n", quote(strings.ToUpper(n)), quote(s), quote(date.UTC().Format("2006-01-02")), quote(source), quote(m.Title), manual(d))
+	m.errors(f.Close())
+	return e
+}
+
+// This writes a man page for every file, skipping those whose page is newer
+// than both the markdown and the template, and records the settings in the
+// manifest.
+func (m *Markdown) man(r Renderer) error {
+	for i := range m.files {
+		m.errors(m.roff(r, m.files[i]))
+	}
+	m.remember(manifest{Files: m.inputs(), Settings: m.settings()})
+	return nil
+}
@@ -0,0 +1,73 @@
+package static
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestManual(t *testing.T) {
+	in := `<h1>NAME</h1>
+<p>smd - <em>static</em> <strong>markdown <code>--web</code></strong></p>
+<h3>Options</h3>
+<ul>
+<li>one</li>
+<li>two<ol><li>nested</li></ol></li>
+</ul>
+<pre><code>.hidden \ path
+  indented
+</code></pre>
+<blockquote><p>see <a href="https://example.com">site</a> and <a href="other.md">other</a></p></blockquote>`
+	out := `.SH
+NAME
+.PP
+smd \- \fIstatic\fR \fBmarkdown \fB\-\-web\fB\fR
+.SS
+Options
+.IP \(bu 2
+one
+.IP \(bu 2
+two
+.RS 4
+.IP "1." 4
+nested
+.RE
+.PP
+.RS 4
+.nf
+\&.hidden \e path
+  indented
+.fi
+.RE
+.RS 4
+.PP
+see site <https://example.com> and other
+.RE`
+	if s := manual([]byte(in)); s != out {
+		t.Errorf("unexpected roff:\n%s", s)
+	}
+}
+
+func TestMarkdownMan(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{
+		"smd.md":          "---\ndate: 2017-06-01\n---\n<p>tool</p>",
+		"smdd.8.md":       "daemon",
+		"etc/smd.conf.md": "---\nsection: 5\n---\nconfig",
+	})
+	defer cleanup()
+
+	// each page is written to the section from its file name or front matter
+	m := &Markdown{L: &mockLogger{}, Input: d, Title: "Static", Version: "1.0", Man: true}
+	if e := m.Run(Operation(func(b []byte) []byte { return b })); e != nil {
+		t.Fatal(e)
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(d, "man", "smd.1")); string(b) != ".TH \"SMD\" \"1\" \"2017-06-01\" \"Static 1.0\" \"Static\"\n.PP\ntool\n" {
+		t.Errorf("unexpected page: %q", b)
+	}
+	for _, f := range []string{"smdd.8", filepath.Join("etc", "smd.conf.5")} {
+		if b, _ := ioutil.ReadFile(filepath.Join(d, "man", f)); !strings.HasPrefix(string(b), ".TH ") {
+			t.Errorf("expected %s to be written, got %q", f, b)
+		}
+	}
+}
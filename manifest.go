@@ -0,0 +1,146 @@
+package static
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The cache directory holding the manifest of each output, which tests may
+// replace.
+var cachedir = os.UserCacheDir
+
+// The record of the previous build, kept in the cache directory, so that a build
+// can tell when files were added or removed, which the modified times of the
+// remaining files cannot.
+//
+// Files are relative to the input path and separated by slashes, Settings is
+// a checksum of the options that change the output, Links is a checksum of
+// the navigation given to every page in web mode, and Content
+// holds the html of each page without the template, when it is needed by the
+// search index or feeds.
+type manifest struct {
+	Files    []string          `json:"files"`
+	Settings string            `json:"settings,omitempty"`
+	Links    string            `json:"links,omitempty"`
+	Content  map[string]string `json:"content,omitempty"`
+}
+
+// This reports whether the files differ from those of the previous build.
+func (x manifest) changed(files []string) bool {
+	return strings.Join(x.Files, "\x00") != strings.Join(files, "\x00")
+}
+
+// This sums the path, title and front matter of every link, which covers
+// everything in the navigation that is not relative to the current page.  The
+// modified time used as the date of a page without one is left out, since it
+// changes with every edit.
+func checksum(links []Link) string {
+	h := sha256.New()
+	for i := range links {
+		json.NewEncoder(h).Encode(struct {
+			Path, Title string
+			Page        Page
+		}{links[i].Path, links[i].Title, links[i].Page})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// This sums every option that changes the output, including the paths of the
+// templates, so that changing any of them rebuilds everything even though no
+// markdown file is newer than its output.
+func (m *Markdown) settings() string {
+	h := sha256.New()
+	json.NewEncoder(h).Encode(struct {
+		Title, Template, TemplatesDir, Version, Author, BaseURL  string
+		Web, SelfContained, Epub, Man, Slides, Highlight, Search bool
+		Feed, FeedSection, SearchFields                          string
+		FeedEntries, SearchBody                                  int
+	}{
+		m.Title, m.Template, m.TemplatesDir, m.Version, m.Author, m.BaseURL,
+		m.Web, m.SelfContained, m.Epub, m.Man, m.Slides, m.Highlight, m.Search,
+		m.Feed, m.FeedSection, m.SearchFields,
+		m.FeedEntries, m.SearchBody,
+	})
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// The path of the manifest, which is named by a checksum of the absolute
+// output path within the cache directory, so that it is never published with
+// the output nor written anywhere the output path alone might suggest.
+func (m *Markdown) ledger() (string, error) {
+	d, e := cachedir()
+	if e != nil {
+		return "", e
+	}
+	o, e := filepath.Abs(m.Output)
+	if e != nil {
+		return "", e
+	}
+	s := sha256.Sum256([]byte(o))
+	return filepath.Join(d, "smd", hex.EncodeToString(s[:])+".json"), nil
+}
+
+// The files of the current build, relative to the input path.
+func (m *Markdown) inputs() []string {
+	l := make([]string, len(m.files))
+	for i := range m.files {
+		l[i] = filepath.ToSlash(within(m.Input, m.files[i]))
+	}
+	return l
+}
+
+// This reads the manifest of the previous build, which is empty when there
+// was none or it cannot be read, so that everything is rebuilt.
+func (m *Markdown) previous() manifest {
+	var x manifest
+	f, e := m.ledger()
+	if e != nil {
+		return x
+	}
+	in, e := open(f)
+	if e != nil {
+		return x
+	}
+	defer in.Close()
+	if b, e := readall(in); e == nil {
+		json.Unmarshal(b, &x)
+	}
+	return x
+}
+
+// This records the manifest of the current build.
+//
+// Since the output is already complete a failure is only logged, and any
+// manifest left by an earlier build is removed so that the next build renders
+// everything again.
+func (m *Markdown) remember(x manifest) {
+	f, e := m.ledger()
+	if e == nil {
+		if e = record(f, x); e != nil {
+			remove(f)
+		}
+	}
+	if e != nil {
+		m.L.Info("Unable to record the build, so the next will be complete: %v", e)
+	}
+}
+
+// This writes the manifest to the supplied file.
+func record(file string, x manifest) error {
+	if e := mkdirall(filepath.Dir(file), os.ModePerm); e != nil {
+		return e
+	}
+	out, e := create(file)
+	if e != nil {
+		return e
+	}
+	if e = json.NewEncoder(out).Encode(x); e != nil {
+		out.Close()
+		return e
+	}
+	return out.Close()
+}
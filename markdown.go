@@ -2,17 +2,27 @@
 package static
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
 	"html/template"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
+	"time"
 )
 
 var readall = ioutil.ReadAll
 var open = os.Open
 var create = os.Create
 var mkdirall = os.MkdirAll
+var stat = os.Stat
+var tempfile = ioutil.TempFile
 
 type logger interface {
 	Info(string, ...interface{})
@@ -20,7 +30,9 @@ type logger interface {
 	Error(string, ...interface{})
 }
 
-type operation func([]byte) []byte
+// A marker used in place of the content when executing the book template, so
+// that the output can be split and each file streamed in its place.
+const marker = "\x00static:content\x00"
 
 // This is the compiler that collects the list markdown files, a title, the
 // input and output paths, and whether to produce multiple files (web mode) or
@@ -29,16 +41,47 @@ type operation func([]byte) []byte
 // All public properties are not thread safe, so concurrent execution may yield
 // errors if those properties are being modified or accessed in parallel.
 type Markdown struct {
-	Title    string `json:"title,omitempty"`
-	Input    string `json:"input,omitempty"`
-	Output   string `json:"output,omitempty"`
-	Web      bool   `json:"web,omitempty"`
-	Template string `json:"template,omitempty"`
-	Version  string `json:"version,omitempty"`
-	L        logger `json:"-"`
+	Title         string `json:"title,omitempty"`
+	Input         string `json:"input,omitempty"`
+	Output        string `json:"output,omitempty"`
+	Web           bool   `json:"web,omitempty"`
+	Template      string `json:"template,omitempty"`
+	TemplatesDir  string `json:"templates-dir,omitempty"`
+	Version       string `json:"version,omitempty"`
+	Force         bool   `json:"force,omitempty"`
+	Jobs          int    `json:"jobs,omitempty"`
+	Include       string `json:"include,omitempty"`
+	Exclude       string `json:"exclude,omitempty"`
+	SelfContained bool   `json:"self-contained,omitempty"`
+	Epub          bool   `json:"epub,omitempty"`
+	Man           bool   `json:"man,omitempty"`
+	Slides        bool   `json:"slides,omitempty"`
+	Author        string `json:"author,omitempty"`
+	Highlight     bool   `json:"highlight,omitempty"`
+	Watch         bool   `json:"watch,omitempty"`
+	Serve         bool   `json:"serve,omitempty"`
+	Host          string `json:"host,omitempty"`
+	Port          int    `json:"port,omitempty"`
+	BaseURL       string `json:"base-url,omitempty"`
+	Feed          string `json:"feed,omitempty"`
+	FeedSection   string `json:"feed-section,omitempty"`
+	FeedEntries   int    `json:"feed-entries,omitempty"`
+	Search        bool   `json:"search,omitempty"`
+	SearchFields  string `json:"search-fields,omitempty"`
+	SearchBody    int    `json:"search-body,omitempty"`
+	L             logger `json:"-"`
 
-	err   error
-	files []string
+	err     error
+	files   []string
+	assets  []string
+	layouts []string
+	sources map[string]string
+	reload  func()
+	dirty   bool
+	ctx     context.Context
+
+	mu       sync.Mutex
+	rendered map[string][]byte
 }
 
 // This function helps us handle any errors encountered during processing
@@ -46,14 +89,16 @@ type Markdown struct {
 //
 // It also is responsible for logging every error encountered.
 //
-// If the error is nil it ignores it, thus the last non-nil error will be
-// returned, so that the caller knows at least one failure has occurred.
-func (m *Markdown) errors(err error) {
-	if err == nil {
-		return
-	}
-	m.L.Error(err.Error())
-	m.err = err
+// Any nil errors are ignored, thus the last non-nil error will be returned,
+// so that the caller knows at least one failure has occurred.
+func (m *Markdown) errors(errs ...error) {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		m.L.Error(err.Error())
+		m.err = err
+	}
 }
 
 // If the absolute path minus the file extension already exist then we want to
@@ -81,6 +126,10 @@ func (m *Markdown) valid(file string) bool {
 // When walking through files we collect errors but do not return them, so that
 // the entire operation is not canceled due to a single failure.
 //
+// The output directory is skipped entirely, since it may be nested inside of
+// the input path.  Paths are compared as absolute paths, since the input and
+// output may be supplied as one absolute and one relative path.
+//
 // If there is an error, the file is a directory, the file is irregular, the
 // file does not have a markdown extension, or the file name minus its
 // extention is already in our list, then we skip that file.
@@ -89,136 +138,461 @@ func (m *Markdown) valid(file string) bool {
 // with multiple valid markdown extensions for the same file basename.
 //
 // Each verified file is added to the list of files, which we will process
-// after we finish iterating all files.
+// after we finish iterating all files.  In web mode directory layouts are
+// collected, and any other file that is included is added to the list of
+// assets to be copied.
 func (m *Markdown) walk(file string, f os.FileInfo, e error) error {
 	m.errors(e)
-	if e != nil || f.IsDir() || !f.Mode().IsRegular() || f.Size() == 0 || !m.valid(file) || m.matches(file) {
+	if e == nil && f.IsDir() && file != m.Input && m.output(file) {
+		return filepath.SkipDir
+	}
+	if e != nil || f.IsDir() || !f.Mode().IsRegular() {
+		return nil
+	}
+	if m.Web && filepath.Base(file) == layoutFile {
+		m.layouts = append(m.layouts, file)
+		return nil
+	}
+	if !m.valid(file) {
+		if m.Web && m.include(file) {
+			m.assets = append(m.assets, file)
+		}
+		return nil
+	}
+	if f.Size() == 0 || m.matches(file) {
 		return nil
 	}
 	m.files = append(m.files, file)
 	return nil
 }
 
-// A way to abstract the process of getting a template
-func (m *Markdown) template() (*template.Template, error) {
-	if m.Template != "" {
-		return template.ParseFiles(m.Template)
+// The context of the current run, which is passed to the Renderer, or the
+// background context outside of one.
+func (m *Markdown) context() context.Context {
+	if m.ctx == nil {
+		return context.Background()
 	}
-	var assetFile = "templates/book.tmpl"
-	if m.Web {
-		assetFile = "templates/web.tmpl"
+	return m.ctx
+}
+
+// This reports whether the directory is the output, comparing absolute paths.
+func (m *Markdown) output(dir string) bool {
+	d, e := filepath.Abs(dir)
+	if e != nil {
+		return false
+	}
+	o, e := filepath.Abs(m.Output)
+	return e == nil && d == o
+}
+
+// The path of a file relative to the base path, comparing absolute paths, so
+// that an input such as `.` does not take the leading dot from hidden files.
+// The file is returned as it is when either path cannot be made absolute.
+func within(base, file string) string {
+	b, e := filepath.Abs(base)
+	if e != nil {
+		return file
 	}
-	d, e := Asset(assetFile)
+	f, e := filepath.Abs(file)
+	if e != nil {
+		return file
+	}
+	if r, e := filepath.Rel(b, f); e == nil {
+		return r
+	}
+	return file
+}
+
+// The name of the embedded template matching the current output mode.
+func (m *Markdown) asset() string {
+	return "templates/" + m.mode() + ".tmpl"
+}
+
+// A way to abstract the process of getting a template, with the functions
+// from funcs registered for a page at the root of the output.
+//
+// The set is empty apart from the layouts it holds, and the embedded template
+// for the current mode is always parsed first, so that it is used when no
+// replacement is supplied.
+func (m *Markdown) template(r Renderer) (*template.Template, error) {
+	d, e := Asset(m.asset())
 	if e != nil {
 		return nil, e
 	}
-	t := template.New("markdown")
-	return t.Parse(string(d))
+	t := template.New("static").Funcs(m.funcs(r, ""))
+	if _, e := t.New(m.mode()).Parse(string(d)); e != nil {
+		return nil, e
+	}
+	return t, m.parse(t)
+}
+
+// The newest modified time of the templates, including the time recorded by
+// bindata for the embedded template.
+func (m *Markdown) modified() (time.Time, error) {
+	f, e := AssetInfo(m.asset())
+	if e != nil {
+		return time.Time{}, e
+	}
+	t := f.ModTime()
+	for _, file := range m.templates() {
+		f, e := stat(file)
+		if e != nil {
+			return time.Time{}, e
+		}
+		if f.ModTime().After(t) {
+			t = f.ModTime()
+		}
+	}
+	return t, nil
+}
+
+// An output is stale when it does not exist, or when the template or any of
+// the supplied inputs were modified after it.
+//
+// Any failure to read the modified times is treated as stale, so that we
+// err on the side of rebuilding, and the Force property bypasses the check, as
+// does any change to the settings since the previous build.
+func (m *Markdown) stale(out string, in ...string) bool {
+	if m.Force || m.dirty {
+		return true
+	}
+	o, e := stat(out)
+	if e != nil {
+		return true
+	}
+	if t, e := m.modified(); e != nil || t.After(o.ModTime()) {
+		return true
+	}
+	for i := range in {
+		if f, e := stat(in[i]); e != nil || f.ModTime().After(o.ModTime()) {
+			return true
+		}
+	}
+	return false
+}
+
+// The path of the html file produced in web mode from the supplied markdown
+// file, which mirrors the directory structure of the input path.
+func (m *Markdown) path(file string) string {
+	return filepath.Join(m.Output, strings.TrimSuffix(within(m.Input, file), filepath.Ext(file))+".html")
+}
+
+// This renders a single markdown file into its matching html file, with any
+// front matter removed and passed to the template as the Page, along with the
+// navigation for every page relative to this one.  Headings are given anchors
+// that are unique within the page, and links to other markdown files are
+// rewritten to their html.
+//
+// The layout is chosen for each page, from the front matter, the nearest
+// directory layout, or the layout for web mode.
+//
+// Errors are returned in the order they occur, including nil errors, instead
+// of being handled directly, so that pages may be rendered concurrently while
+// the errors are still reported in a deterministic order.
+func (m *Markdown) page(t *template.Template, r Renderer, file string, links []Link) []error {
+	in, e := open(file)
+	if e != nil {
+		return []error{e}
+	}
+	b, e := readall(in)
+	errs := []error{in.Close()}
+	if e != nil {
+		return append(errs, e)
+	}
+	p, d, e := m.render(r, file, b)
+	if e != nil {
+		return append(errs, e)
+	}
+	d = (&anchors{}).anchor(m.relink(file, d))
+	m.keep(file, d)
+	name, e := m.choose(t, file, p)
+	if e != nil {
+		return append(errs, e)
+	}
+	if t, e = m.layout(t, name, r, m.url(file)); e != nil {
+		return append(errs, e)
+	}
+	errs = append(errs, mkdirall(filepath.Dir(m.path(file)), os.ModePerm))
+	out, e := create(m.path(file))
+	if e != nil {
+		return append(errs, e)
+	}
+
+	l, n := navigate(links, m.url(file))
+	var search string
+	if m.Search {
+		search = relative(m.url(file), "search.js")
+	}
+	errs = append(errs, t.Execute(out, struct {
+		Title   string
+		Name    string
+		Content template.HTML
+		Version string
+		Page    Page
+		Pages   []Link
+		Tree    *Node
+		Search  string
+	}{
+		Content: template.HTML(string(d)),
+		Title:   m.Title,
+		Name:    strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)),
+		Version: m.Version,
+		Page:    p,
+		Pages:   l,
+		Tree:    n,
+		Search:  search,
+	}))
+	return append(errs, out.Close())
 }
 
 // This operation processes each file independently, which includes passing to
 // each its own page structure.
 //
-// In the future, when buffered markdown parsers exist, this should leverage
-// concurrency, but the current implementation is bottlenecked at disk IO.
+// Rendering is bound by the markdown parser rather than the disk, so pages are
+// rendered by a pool of workers, defaulting to one per processor.  The results
+// are kept by index, and errors are reported in file order once all workers
+// have finished, so the output is identical to rendering sequentially.
 //
 // The template is created first, using the compiled bindata by default, or the
-// supplied template file if able.
-func (m *Markdown) web(o operation) error {
-	t, e := m.template()
+// supplied template file if able, and is shared by every worker along with the
+// Renderer, which must therefore be safe for concurrent use.
+//
+// Files whose html output is newer than both the markdown and the template
+// are skipped, unless they are in the set of changed files supplied by watch
+// mode, or pages were added, removed or retitled since the previous build, in
+// which case every page is rendered so that the navigation is current.  The
+// html of any file removed since the previous build is deleted.
+//
+// The html of every page rendered is kept for the search index and feeds.
+// When either is enabled it is also recorded in the manifest, so that pages
+// skipped by a later build are not rendered again just to index them.
+//
+// Finally the search index is written when Search is set, and any feeds and
+// a sitemap are written when a BaseURL has been supplied.
+func (m *Markdown) web(r Renderer, changed map[string]bool) error {
+	t, e := m.template(r)
 	if e != nil {
 		return e
 	}
+	links := m.links()
+	prev, files := m.previous(), m.inputs()
+	for _, f := range prev.Files {
+		if file := filepath.Join(m.Input, filepath.FromSlash(f)); !m.matches(file) {
+			if e := remove(m.path(file)); e != nil && !os.IsNotExist(e) {
+				m.errors(e)
+			}
+		}
+	}
+	sum := checksum(links)
+	all := prev.changed(files) || prev.Links != sum
+	m.rendered = make(map[string][]byte)
+	jobs := m.Jobs
+	if jobs < 1 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+	var wg sync.WaitGroup
+	queue := make(chan int)
+	results := make([][]error, len(m.files))
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				results[i] = m.page(t, r, m.files[i], links)
+			}
+		}()
+	}
+	for i := range m.files {
+		if !all && !changed[m.files[i]] && !m.stale(m.path(m.files[i]), m.files[i]) {
+			m.L.Debug("Skipping unmodified file: %s", m.files[i])
+			continue
+		}
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+	for i := range results {
+		m.errors(results[i]...)
+	}
+	for i, f := range files {
+		if results[i] == nil && prev.Content[f] != "" {
+			m.rendered[m.files[i]] = []byte(prev.Content[f])
+		}
+	}
+	m.errors(m.feeds(links, r))
+	m.errors(m.search(links, r))
+	m.errors(m.sitemap(links))
+	x := manifest{Files: files, Settings: m.settings(), Links: sum}
+	if m.Search || m.Feed != "" {
+		x.Content = make(map[string]string)
+		for i, f := range files {
+			if b, ok := m.rendered[m.files[i]]; ok {
+				x.Content[f] = string(b)
+			}
+		}
+	}
+	m.remember(x)
+	return nil
+}
+
+// This writes each file through the markdown processor `Renderer` and into
+// the writer in order, so that only one file is held in memory at a time.
+//
+// Any front matter is removed, since a book has no per-page metadata, each
+// file starts with an anchor named after its path, every heading is given an
+// anchor, links between files are replaced with placeholders, and assets are
+// inlined when the book is self contained.
+func (m *Markdown) chapters(w io.Writer, r Renderer, a *anchors) {
 	for i := range m.files {
 		in, e := open(m.files[i])
 		if e != nil {
 			m.errors(e)
 			continue
 		}
-		b, e := readall(in)
+		d, e := readall(in)
 		m.errors(in.Close())
 		if e != nil {
 			m.errors(e)
 			continue
 		}
-		d := o(b)
-		m.errors(mkdirall(filepath.Dir(filepath.Join(m.Output, strings.TrimSuffix(strings.TrimPrefix(m.files[i], m.Input), filepath.Ext(m.files[i]))+".html")), os.ModePerm))
-		out, e := create(filepath.Join(m.Output, strings.TrimSuffix(strings.TrimPrefix(m.files[i], m.Input), filepath.Ext(m.files[i]))+".html"))
-		if e != nil {
+		if _, d, e = m.render(r, m.files[i], d); e != nil {
 			m.errors(e)
 			continue
 		}
-
-		if e := t.Execute(out, struct {
-			Title   string
-			Name    string
-			Content template.HTML
-			Version string
-		}{
-			Content: template.HTML(string(d)),
-			Title:   m.Title,
-			Name:    strings.TrimSuffix(filepath.Base(m.files[i]), filepath.Ext(m.files[i])),
-			Version: m.Version,
-		}); e != nil {
-			m.errors(e)
+		n := strings.TrimSuffix(within(m.Input, m.files[i]), filepath.Ext(m.files[i]))
+		_, e = fmt.Fprintf(w, `<a id="%s"></a>`, a.begin(m.files[i], strings.Replace(strings.Trim(filepath.ToSlash(n), "/"), "/", " ", -1)))
+		m.errors(e)
+		d = a.anchor(m.crosslink(m.files[i], d))
+		if m.SelfContained {
+			d = m.inline(filepath.Dir(m.files[i]), d)
 		}
-		m.errors(out.Close())
+		_, e = w.Write(d)
+		m.errors(e)
 	}
-	return nil
 }
 
-// This operation processes each file sequentially, and keeps the bytes for all
-// files in memory so it can write the output to a single file.
+// This operation processes each file sequentially, and streams the output to
+// a single file so that the bytes for all files are never held in memory.
+//
+// The files are first streamed through the markdown processor `Renderer`
+// into a temporary file, which collects the headings for the table of
+// contents.  The template is then executed once with a marker in place of the
+// content, and the result is split around that marker.  We write everything
+// before the marker, copy the temporary file, and finish with everything after
+// it.  If the template uses the content more than once, the temporary file is
+// copied again at each occurrence.
 //
-// In the future, it would be best if each file were loaded into a buffered
-// markdown parser and piped to a buffered template so that the system could
-// avoid storing all bytes in memory.
+// Links between files are resolved while copying, since a link may point at a
+// heading in a file that has not yet been processed.
 //
-// Once every file has been loaded into a single byte array, we run it through
-// the markdown processor `operation`, and pass that into a template which then
-// pushes the output to a single file.
-func (m *Markdown) book(o operation) error {
-	t, e := m.template()
+// When SelfContained is set, local images and linked files such as stylesheets
+// are inlined as data uris, so the output can be moved on its own.  Paths in
+// markdown are relative to the file, and paths in the template are relative
+// to the template, to the TemplatesDir, or to the input path for the embedded
+// template.
+//
+// Each file is processed independently, so markdown constructs such as
+// reference links cannot span files.
+func (m *Markdown) book(r Renderer) error {
+	t, e := m.template(r)
 	if e != nil {
 		return e
 	}
-	var b []byte
-	for i := range m.files {
-		in, e := open(m.files[i])
-		if e != nil {
-			m.errors(e)
-			continue
-		}
-		d, e := readall(in)
-		m.errors(in.Close())
-		if e != nil {
-			m.errors(e)
-			continue
-		}
-		b = append(b, d...)
+	if t, e = m.layout(t, m.mode(), r, ""); e != nil {
+		return e
 	}
-	d := o(b)
-	m.errors(mkdirall(filepath.Dir(m.Output), os.ModePerm))
-	out, e := create(m.Output)
+	tmp, e := tempfile(os.TempDir(), "static-book")
 	if e != nil {
 		return e
 	}
-	defer out.Close()
-	return t.Execute(out, struct {
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	a := &anchors{}
+	w := bufio.NewWriter(tmp)
+	m.chapters(w, r, a)
+	if e := w.Flush(); e != nil {
+		return e
+	}
+
+	var b bytes.Buffer
+	if e := t.Execute(&b, struct {
 		Title   string
 		Content template.HTML
 		Version string
+		TOC     []*Heading
 	}{
-		Content: template.HTML(string(d)),
+		Content: template.HTML(marker),
 		Title:   m.Title,
 		Version: m.Version,
-	})
+		TOC:     a.toc(),
+	}); e != nil {
+		return e
+	}
+	parts := bytes.Split(m.contain(b.Bytes()), []byte(marker))
+	m.errors(mkdirall(filepath.Dir(m.Output), os.ModePerm))
+	out, e := create(m.Output)
+	if e != nil {
+		return e
+	}
+	defer out.Close()
+	w = bufio.NewWriter(out)
+	w.Write(parts[0])
+	for i := range parts[1:] {
+		if _, e := tmp.Seek(0, io.SeekStart); e != nil {
+			return e
+		}
+		if e := a.resolve(w, tmp, m.L.Info); e != nil {
+			return e
+		}
+		w.Write(parts[i+1])
+	}
+	return w.Flush()
 }
 
-// The primary function, which accepts the operation used to convert markdown
+// This inlines the assets referenced by an executed template when the output
+// is self contained, relative to the template, to the TemplatesDir, or to the
+// input path for the embedded template.
+func (m *Markdown) contain(h []byte) []byte {
+	if m.SelfContained && m.Template != "" {
+		return m.inline(filepath.Dir(m.Template), h)
+	} else if m.SelfContained && m.TemplatesDir != "" {
+		return m.inline(m.TemplatesDir, h)
+	} else if m.SelfContained {
+		return m.inline(m.Input, h)
+	}
+	return h
+}
+
+// The extension of the single file produced when not in web mode.
+func (m *Markdown) extension() string {
+	if m.Epub {
+		return ".epub"
+	}
+	return ".html"
+}
+
+// This produces the single file for the output modes other than web mode,
+// and records the files it contains.
+func (m *Markdown) single(r Renderer) error {
+	var e error
+	if m.Epub {
+		e = m.epub(r)
+	} else if m.Slides {
+		e = m.slides(r)
+	} else {
+		e = m.book(r)
+	}
+	if e != nil {
+		return e
+	}
+	m.remember(manifest{Files: m.inputs(), Settings: m.settings()})
+	return nil
+}
+
+// The primary function, which accepts the Renderer used to convert markdown
 // into html.  Unfortunately there are currently no markdown parsers that
-// operate on a stream, but in the future I would like to switch to an
-// io.Reader interface.
+// operate on a stream, so each file is converted whole, but the output of
+// book mode is streamed one file at a time.
 //
 // The operation begins by capturing the input path so that we can translate
 // the output path when creating files from the input path, including matching
@@ -227,14 +601,31 @@ func (m *Markdown) book(o operation) error {
 // If no title has been supplied it will default to the parent directories
 // name, but this might be better placed in package main.
 //
-// The default output for web is `public/`, otherwise when in book mode the
-// default is the title.
+// The default output for web is `public/`, and for man pages is `man/`,
+// otherwise when in book or epub mode the default is the title.
 //
 // We walk the input path, which assembles the list of markdown files and then
-// we gather any errors returned.
+// we gather any errors returned, after clearing the files and errors from any
+// previous run.
 //
-// Finally we process the files according to the desired output mode.
-func (m *Markdown) Run(o operation) error {
+// Finally we process the files according to the desired output mode, where
+// book and epub modes are skipped if the output is newer than the template
+// and every markdown file and no file or setting changed since the previous
+// build, web mode copies any assets, and man pages are written for each file
+// that changed.
+//
+// When Watch is set we continue running, and rebuild as files change.  Serve
+// implies Watch, but builds into a temporary directory and serves it with a
+// live reload over http.
+func (m *Markdown) Run(r Renderer) error {
+	return m.RunContext(context.Background(), r)
+}
+
+// RunContext is Run with a context, which is passed to the Renderer for every
+// file, so that cancelling it stops any external renderer, and which ends
+// watching and serving once it is done.
+func (m *Markdown) RunContext(ctx context.Context, r Renderer) error {
+	m.ctx = ctx
 	var e error
 	if m.Input == "" {
 		if m.Input, e = os.Getwd(); e != nil {
@@ -247,15 +638,47 @@ func (m *Markdown) Run(o operation) error {
 	}
 	if m.Web && m.Output == "" {
 		m.Output = filepath.Join(m.Input, "public")
+	} else if m.Man && m.Output == "" {
+		m.Output = filepath.Join(m.Input, "man")
 	} else if m.Output == "" {
-		m.Output = filepath.Join(m.Input, m.Title+".html")
+		m.Output = filepath.Join(m.Input, m.Title+m.extension())
+	}
+	if m.Serve {
+		d, e := m.preview()
+		if e != nil {
+			m.errors(e)
+			return e
+		}
+		defer os.RemoveAll(d)
+		if f, e := m.ledger(); e == nil {
+			defer os.Remove(f)
+		}
 	}
+	m.err, m.files, m.assets, m.layouts = nil, nil, nil, nil
 	m.errors(filepath.Walk(m.Input, m.walk))
+	m.dirty = m.previous().Settings != m.settings()
 	m.L.Debug("Status: %#v", m)
 	if m.Web {
-		m.errors(m.web(o))
+		m.errors(m.web(r, nil))
+		m.copy()
+	} else if m.Man {
+		m.errors(m.man(r))
+	} else if m.stale(m.Output, m.files...) || m.previous().changed(m.inputs()) {
+		m.errors(m.single(r))
 	} else {
-		m.errors(m.book(o))
+		m.L.Debug("Skipping unmodified book: %s", m.Output)
+	}
+	m.dirty = false
+	if m.Serve {
+		l, e := m.serve()
+		if e != nil {
+			m.errors(e)
+			return e
+		}
+		defer l.Close()
+	}
+	if m.Watch || m.Serve {
+		m.watch(r)
 	}
 	return m.err
 }
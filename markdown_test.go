@@ -1,10 +1,17 @@
 package static
 
 import (
+	"errors"
 	"io"
 	"io/ioutil"
+	"net"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 type mockLogger struct{}
@@ -13,8 +20,47 @@ func (l *mockLogger) Debug(string, ...interface{}) {}
 func (l *mockLogger) Info(string, ...interface{})  {}
 func (l *mockLogger) Error(string, ...interface{}) {}
 
+// This restores every package level function that tests replace, so that no
+// test depends on the order in which they run.
+func restore() {
+	readall, open, create, mkdirall, stat, tempfile = ioutil.ReadAll, os.Open, os.Create, os.MkdirAll, os.Stat, ioutil.TempFile
+	listen, tempdir, remove, tick = net.Listen, ioutil.TempDir, os.Remove, time.Tick
+	getenv, command, oversized, cachedir = os.Getenv, exec.CommandContext, 1<<20, os.UserCacheDir
+}
+
+// This creates a temporary input path holding the supplied files, keyed by
+// their path relative to the input and separated by slashes, along with a
+// temporary cache directory, and returns it with a function that removes both
+// and restores every replaced function.
+func fixture(t *testing.T, files map[string]string) (string, func()) {
+	restore()
+	d, e := ioutil.TempDir(os.TempDir(), "static")
+	if e != nil {
+		t.Fatal(e)
+	}
+	c, e := ioutil.TempDir(os.TempDir(), "static-cache")
+	if e != nil {
+		t.Fatal(e)
+	}
+	cachedir = func() (string, error) { return c, nil }
+	for f, b := range files {
+		f = filepath.Join(d, filepath.FromSlash(f))
+		os.MkdirAll(filepath.Dir(f), os.ModePerm)
+		if e := ioutil.WriteFile(f, []byte(b), 0644); e != nil {
+			t.Fatal(e)
+		}
+	}
+	return d, func() {
+		os.RemoveAll(d)
+		os.RemoveAll(c)
+		restore()
+	}
+}
+
 func TestMarkdown(t *testing.T) {
+	defer restore()
 	var files []*os.File
+	var mu sync.Mutex
 
 	// abstract behaviors
 	o := func(b []byte) []byte { return b }
@@ -22,24 +68,28 @@ func TestMarkdown(t *testing.T) {
 	mkdirall = func(d string, f os.FileMode) error { return nil }
 	create = func(n string) (*os.File, error) {
 		tfo, e := ioutil.TempFile(os.TempDir(), "static-out")
+		mu.Lock()
 		files = append(files, tfo)
+		mu.Unlock()
 		return tfo, e
 	}
 	open = func(n string) (*os.File, error) {
 		tfi, e := ioutil.TempFile(os.TempDir(), "static-in")
+		mu.Lock()
 		files = append(files, tfi)
+		mu.Unlock()
 		return tfi, e
 	}
 
 	// execute operation book mode
 	m := &Markdown{L: &mockLogger{}}
-	if e := m.Run(o); e != nil {
+	if e := m.Run(Operation(o)); e != nil {
 		t.Error(e)
 	}
 
 	// execute operation web mode
 	m.Web = true
-	if e := m.Run(o); e != nil {
+	if e := m.Run(Operation(o)); e != nil {
 		t.Error(e)
 	}
 
@@ -48,3 +98,177 @@ func TestMarkdown(t *testing.T) {
 		os.Remove(files[i].Name())
 	}
 }
+
+func TestMarkdownIncremental(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{"page.md": "# page"})
+	defer cleanup()
+	var created int
+	create = func(n string) (*os.File, error) {
+		if filepath.Ext(n) == ".html" {
+			created++
+		}
+		return os.Create(n)
+	}
+	o := func(b []byte) []byte { return b }
+
+	// the second run should skip the unmodified file
+	m := &Markdown{L: &mockLogger{}, Input: d, Web: true}
+	for i := 0; i < 2; i++ {
+		if e := m.Run(Operation(o)); e != nil {
+			t.Error(e)
+		}
+	}
+	if created != 1 {
+		t.Errorf("expected one file to be created, got %d", created)
+	}
+
+	// force should bypass the modified time check
+	m.Force = true
+	if e := m.Run(Operation(o)); e != nil {
+		t.Error(e)
+	}
+	if created != 2 {
+		t.Errorf("expected forced rebuild, got %d creates", created)
+	}
+
+	// adding a file rebuilds every page, and removing it deletes its html
+	m.Force = false
+	other := filepath.Join(d, "other.md")
+	ioutil.WriteFile(other, []byte("# other"), 0644)
+	if e := m.Run(Operation(o)); e != nil {
+		t.Error(e)
+	}
+	if created != 4 {
+		t.Errorf("expected every page to be rebuilt, got %d creates", created)
+	}
+
+	// retitling a page rebuilds every page, since each holds its title
+	ioutil.WriteFile(other, []byte("---\ntitle: Other\n---\n# other"), 0644)
+	if e := m.Run(Operation(o)); e != nil {
+		t.Error(e)
+	}
+	if created != 6 {
+		t.Errorf("expected every page to be rebuilt after a retitle, got %d creates", created)
+	}
+	os.Remove(other)
+	if e := m.Run(Operation(o)); e != nil {
+		t.Error(e)
+	}
+	if _, e := os.Stat(filepath.Join(d, "public", "other.html")); !os.IsNotExist(e) {
+		t.Errorf("expected removed page to be deleted: %v", e)
+	}
+
+	// changing a setting that affects the output rebuilds every page
+	created = 0
+	m.Version = "2"
+	if e := m.Run(Operation(o)); e != nil {
+		t.Error(e)
+	}
+	if created != 1 {
+		t.Errorf("expected a rebuild after changing the version, got %d creates", created)
+	}
+	if e := m.Run(Operation(o)); e != nil {
+		t.Error(e)
+	}
+	if created != 1 {
+		t.Errorf("expected the unchanged settings to be skipped, got %d creates", created)
+	}
+
+	// the manifest is kept out of the output, and failing to record it only
+	// means the next build is complete
+	if l, _ := filepath.Glob(filepath.Join(d, "*.smd")); len(l) > 0 {
+		t.Errorf("unexpected manifest beside the output: %v", l)
+	}
+	create = func(n string) (*os.File, error) {
+		if filepath.Ext(n) == ".json" {
+			return nil, os.ErrPermission
+		}
+		return os.Create(n)
+	}
+	m.Force = true
+	if e := m.Run(Operation(o)); e != nil {
+		t.Errorf("expected a failure to record the build to be ignored: %v", e)
+	}
+	m.Force, created = false, 0
+	create = func(n string) (*os.File, error) {
+		if filepath.Ext(n) == ".html" {
+			created++
+		}
+		return os.Create(n)
+	}
+	if e := m.Run(Operation(o)); e != nil {
+		t.Error(e)
+	}
+	if created != 1 {
+		t.Errorf("expected a complete build without a manifest, got %d creates", created)
+	}
+}
+
+type recordLogger struct {
+	mockLogger
+	errors []string
+}
+
+func (l *recordLogger) Error(f string, a ...interface{}) { l.errors = append(l.errors, f) }
+
+func TestMarkdownJobs(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{"a.md": "a", "b.md": "b", "c.md": "c", "d.md": "d", "e.md": "e"})
+	defer cleanup()
+	expect := []string{"a.html", "b.html", "c.html", "d.html", "e.html"}
+	mkdirall = func(string, os.FileMode) error { return nil }
+	create = func(n string) (*os.File, error) {
+		if filepath.Ext(n) != ".html" {
+			return os.Create(n)
+		}
+		return nil, errors.New(filepath.Base(n))
+	}
+
+	// errors from concurrent workers are reported in file order
+	l := &recordLogger{}
+	m := &Markdown{L: l, Input: d, Web: true, Jobs: 4}
+	if e := m.Run(Operation(func(b []byte) []byte { return b })); e == nil {
+		t.Error("expected an error")
+	}
+	if strings.Join(l.errors, ",") != strings.Join(expect, ",") {
+		t.Errorf("expected errors in file order, got %v", l.errors)
+	}
+}
+
+func TestMarkdownBook(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{
+		"a.md":      "first",
+		"b.md":      "second",
+		"book.tmpl": "<h1>{{.Title}}</h1>{{.Content}}<p>{{.Version}}</p>",
+	})
+	defer cleanup()
+
+	// each file is streamed in order between the template halves
+	m := &Markdown{L: &mockLogger{}, Input: d, Title: "Book", Version: "1.0", Template: filepath.Join(d, "book.tmpl")}
+	if e := m.Run(Operation(func(b []byte) []byte { return append([]byte("<p>"), append(b, "</p>"...)...) })); e != nil {
+		t.Error(e)
+	}
+	if b, _ := ioutil.ReadFile(m.Output); string(b) != `<h1>Book</h1><a id="chapter-a"></a><p>first</p><a id="chapter-b"></a><p>second</p><p>1.0</p>` {
+		t.Errorf("unexpected book: %s", b)
+	}
+
+	// removing a file rebuilds the book even though the rest are unmodified
+	os.Remove(filepath.Join(d, "b.md"))
+	if e := m.Run(Operation(func(b []byte) []byte { return b })); e != nil {
+		t.Error(e)
+	}
+	if b, _ := ioutil.ReadFile(m.Output); strings.Contains(string(b), "second") {
+		t.Errorf("expected removed file to leave the book: %s", b)
+	}
+
+	// switching to a template older than the book still rebuilds it
+	old := filepath.Join(d, "old.tmpl")
+	ioutil.WriteFile(old, []byte("<h2>{{.Title}}</h2>{{.Content}}"), 0644)
+	os.Chtimes(old, time.Unix(0, 0), time.Unix(0, 0))
+	m.Template = old
+	if e := m.Run(Operation(func(b []byte) []byte { return b })); e != nil {
+		t.Error(e)
+	}
+	if b, _ := ioutil.ReadFile(m.Output); !strings.HasPrefix(string(b), "<h2>Book</h2>") {
+		t.Errorf("expected the book to use the new template: %s", b)
+	}
+}
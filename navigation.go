@@ -0,0 +1,131 @@
+package static
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// A page in the site navigation.
+//
+// The Path is relative to the output, while the URL is relative to the page
+// being rendered so that the output works from any location.  The Dir is the
+// directory holding the page, which is empty at the root, and the Depth is the
+// number of directories between it and the root.
+//
+// The Page holds the front matter of the page, and the Date is the date from
+// the front matter, or the modified time of the markdown file when there is
+// none.
+type Link struct {
+	URL     string
+	Path    string
+	Title   string
+	Dir     string
+	Depth   int
+	Current bool
+	Date    time.Time
+	Page    Page
+}
+
+// A directory or page in the navigation tree.
+//
+// Directories have no Link, and Current is set on the page being rendered as
+// well as every directory containing it.
+type Node struct {
+	Name     string
+	Link     *Link
+	Children []*Node
+	Current  bool
+}
+
+// This reads only the front matter for a file, ignoring errors since they
+// are reported when the page itself is rendered.
+func (m *Markdown) meta(file string) Page {
+	in, e := open(file)
+	if e != nil {
+		return Page{}
+	}
+	defer in.Close()
+	b, e := readall(in)
+	if e != nil {
+		return Page{}
+	}
+	p, _, _ := frontmatter(b)
+	return p
+}
+
+// The path of the html file produced from the supplied markdown file, relative
+// to the output and separated by slashes.
+func (m *Markdown) url(file string) string {
+	return filepath.ToSlash(within(m.Output, m.path(file)))
+}
+
+// This collects a link to every page in the same order as the files, with
+// the title taken from the front matter, or the file name when there is none.
+func (m *Markdown) links() []Link {
+	l := make([]Link, 0, len(m.files))
+	for _, file := range m.files {
+		p := m.url(file)
+		meta := m.meta(file)
+		t := meta.Title
+		if t == "" {
+			t = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		}
+		d := path.Dir(p)
+		if d == "." {
+			d = ""
+		}
+		date := meta.Date
+		if f, e := stat(file); e == nil && date.IsZero() {
+			date = f.ModTime()
+		}
+		l = append(l, Link{Path: p, Title: t, Dir: d, Depth: strings.Count(p, "/"), Date: date, Page: meta})
+	}
+	return l
+}
+
+// This produces a copy of the links for the page at the current path, with
+// urls relative to that page and the current page marked, along with the tree
+// built from the directory structure.
+func navigate(links []Link, current string) ([]Link, *Node) {
+	l := make([]Link, len(links))
+	root := &Node{}
+	for i := range links {
+		l[i] = links[i]
+		l[i].URL = relative(current, links[i].Path)
+		l[i].Current = links[i].Path == current
+		n := root
+		if l[i].Dir != "" {
+			for _, d := range strings.Split(l[i].Dir, "/") {
+				n = n.child(d)
+				n.Current = n.Current || l[i].Current
+			}
+		}
+		n.Children = append(n.Children, &Node{Name: path.Base(l[i].Path), Link: &l[i], Current: l[i].Current})
+	}
+	return l, root
+}
+
+// This finds or creates the directory with the supplied name, so directories
+// appear in the order of their first page.
+func (n *Node) child(name string) *Node {
+	for i := range n.Children {
+		if n.Children[i].Link == nil && n.Children[i].Name == name {
+			return n.Children[i]
+		}
+	}
+	c := &Node{Name: name}
+	n.Children = append(n.Children, c)
+	return c
+}
+
+// This builds the url for target relative to the directory of the current
+// page, with both paths relative to the output.
+func relative(current, target string) string {
+	r, e := filepath.Rel(filepath.FromSlash(path.Dir(current)), filepath.FromSlash(target))
+	if e != nil {
+		return "/" + target
+	}
+	return filepath.ToSlash(r)
+}
@@ -0,0 +1,32 @@
+package static
+
+import "testing"
+
+func TestNavigate(t *testing.T) {
+	links := []Link{
+		{Path: "index.html", Title: "Home"},
+		{Path: "guide/install.html", Title: "Install", Dir: "guide", Depth: 1},
+		{Path: "guide/api/types.html", Title: "Types", Dir: "guide/api", Depth: 2},
+		{Path: "zebra.html", Title: "Zebra"},
+	}
+	l, n := navigate(links, "guide/install.html")
+
+	// urls are relative to the current page, which is marked
+	for i, u := range []string{"../index.html", "install.html", "api/types.html", "../zebra.html"} {
+		if l[i].URL != u || l[i].Current != (i == 1) {
+			t.Errorf("unexpected link %#v", l[i])
+		}
+	}
+
+	// the tree nests pages by directory and marks the current path
+	if len(n.Children) != 3 || n.Children[1].Name != "guide" || !n.Children[1].Current || n.Children[2].Link.Title != "Zebra" {
+		t.Fatalf("unexpected tree %#v", n.Children)
+	}
+	g := n.Children[1]
+	if len(g.Children) != 2 || !g.Children[0].Current || g.Children[1].Name != "api" || g.Children[1].Current {
+		t.Errorf("unexpected directory %#v", g.Children)
+	}
+	if links[1].Current || links[1].URL != "" {
+		t.Error("expected the original links to be unmodified")
+	}
+}
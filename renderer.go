@@ -0,0 +1,68 @@
+package static
+
+import (
+	"context"
+	"fmt"
+)
+
+// The markdown for a single file passed to a Renderer, with the front matter
+// already removed and parsed into the Page.
+type Source struct {
+	Path    string
+	Page    Page
+	Content []byte
+}
+
+// The html produced by a Renderer, along with any data it extracted.
+//
+// A Title is used when the front matter has none, and Params are added to the
+// page without replacing any keys from the front matter.
+type Result struct {
+	HTML   []byte
+	Title  string
+	Params map[string]interface{}
+}
+
+// A Renderer converts the markdown for a single file into html.
+//
+// In web mode pages are rendered concurrently, so implementations must be safe
+// for concurrent use.
+type Renderer interface {
+	Render(context.Context, Source) (Result, error)
+}
+
+// An adapter that allows a function converting markdown into html, such as
+// blackfriday.MarkdownCommon, to be used as a Renderer.
+type Operation func([]byte) []byte
+
+// Render passes the markdown to the function, which cannot fail.
+func (o Operation) Render(_ context.Context, s Source) (Result, error) {
+	return Result{HTML: o(s.Content)}, nil
+}
+
+// This removes the front matter and renders the markdown, merging any data
+// returned by the Renderer into the page, and highlights code when enabled.
+//
+// Errors are prefixed with the file, since a renderer may not know it.
+func (m *Markdown) render(r Renderer, file string, b []byte) (Page, []byte, error) {
+	p, b, e := frontmatter(b)
+	if e != nil {
+		return p, nil, fmt.Errorf("%s: %v", file, e)
+	}
+	res, e := r.Render(m.context(), Source{Path: file, Page: p, Content: b})
+	if e != nil {
+		return p, nil, fmt.Errorf("%s: %v", file, e)
+	}
+	if p.Title == "" {
+		p.Title = res.Title
+	}
+	for k, v := range res.Params {
+		if _, ok := p.Params[k]; !ok {
+			p.Params[k] = v
+		}
+	}
+	if m.Highlight {
+		res.HTML = highlight(res.HTML)
+	}
+	return p, res.HTML, nil
+}
@@ -0,0 +1,82 @@
+package static
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type mockRenderer struct {
+	ctx    context.Context
+	source Source
+	result Result
+	err    error
+}
+
+func (r *mockRenderer) Render(ctx context.Context, s Source) (Result, error) {
+	r.ctx, r.source = ctx, s
+	return r.result, r.err
+}
+
+func TestMarkdownRender(t *testing.T) {
+	m := &Markdown{L: &mockLogger{}}
+
+	// the adapter passes only the markdown to the function
+	res, e := Operation(func(b []byte) []byte { return append(b, '!') }).Render(context.Background(), Source{Content: []byte("a")})
+	if e != nil || string(res.HTML) != "a!" {
+		t.Errorf("unexpected adapter result: %q %v", res.HTML, e)
+	}
+
+	// the renderer receives the path and front matter without the markdown
+	r := &mockRenderer{result: Result{HTML: []byte("<p>b</p>"), Title: "Extracted", Params: map[string]interface{}{"author": "renderer", "toc": true}}}
+	p, b, e := m.render(r, "a.md", []byte("---\nauthor: casey\n---\nb"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if r.source.Path != "a.md" || r.source.Page.Author != "casey" || string(r.source.Content) != "b" {
+		t.Errorf("unexpected source: %+v", r.source)
+	}
+	if string(b) != "<p>b</p>" {
+		t.Errorf("unexpected html: %s", b)
+	}
+
+	// extracted data never replaces the front matter
+	if p.Title != "Extracted" || p.Params["author"] != "casey" || p.Params["toc"] != true {
+		t.Errorf("unexpected page: %+v", p)
+	}
+	r.result.Title = "Ignored"
+	if p, _, _ = m.render(r, "a.md", []byte("---\ntitle: Kept\n---\nb")); p.Title != "Kept" {
+		t.Errorf("expected front matter title, got %s", p.Title)
+	}
+
+	// errors are reported with the file
+	r.err = errors.New("failed")
+	if _, _, e = m.render(r, "a.md", []byte("b")); e == nil || e.Error() != "a.md: failed" {
+		t.Errorf("unexpected error: %v", e)
+	}
+}
+
+func TestMarkdownRunContext(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{"page.md": "page"})
+	defer cleanup()
+
+	// the context of the run reaches the renderer
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "run")
+	r := &mockRenderer{result: Result{HTML: []byte("<p>page</p>")}}
+	m := &Markdown{L: &mockLogger{}, Input: d, Web: true}
+	if e := m.RunContext(ctx, r); e != nil {
+		t.Fatal(e)
+	}
+	if r.ctx == nil || r.ctx.Value(key{}) != "run" {
+		t.Errorf("expected the run context, got %v", r.ctx)
+	}
+
+	// cancelling the context ends watching
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	m.Watch, m.Force = true, true
+	if e := m.RunContext(ctx, r); e != nil {
+		t.Error(e)
+	}
+}
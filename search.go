@@ -0,0 +1,128 @@
+package static
+
+import (
+	"encoding/json"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// The fields included for each page in the search index when none are
+// supplied.
+const searchFields = "title,headings,body"
+
+// A page in the search index, where the URL is relative to the index.
+type document struct {
+	URL      string   `json:"url"`
+	Title    string   `json:"title,omitempty"`
+	Headings []string `json:"headings,omitempty"`
+	Body     string   `json:"body,omitempty"`
+}
+
+// The search index, with every term mapped to the ordered list of indexes of
+// the pages containing it.
+type index struct {
+	Pages []document       `json:"pages"`
+	Terms map[string][]int `json:"terms"`
+}
+
+// This converts html into plain text, collapsing all whitespace.
+func plain(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(tags.ReplaceAllString(s, " "))), " ")
+}
+
+// This splits text into lowercase terms of letters and digits, ignoring any
+// single character terms.
+func terms(s string) []string {
+	var t []string
+	for _, f := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) }) {
+		if len([]rune(f)) > 1 {
+			t = append(t, f)
+		}
+	}
+	return t
+}
+
+// This builds the search index from the rendered html of every page except
+// drafts.
+//
+// The terms are collected from the title, headings and full text of every
+// page, while the fields stored for each page are limited to those listed in
+// SearchFields, with the body truncated to SearchBody characters when set.
+//
+// The html of each page is shared with the feeds, and comes from this build or
+// the manifest of the previous one, so pages are only rendered again when
+// neither has them.
+func (m *Markdown) index(links []Link, r Renderer) index {
+	fields := m.SearchFields
+	if fields == "" {
+		fields = searchFields
+	}
+	include := make(map[string]bool)
+	for _, f := range strings.Split(fields, ",") {
+		include[strings.TrimSpace(f)] = true
+	}
+	x := index{Pages: []document{}, Terms: make(map[string][]int)}
+	for i := range links {
+		if links[i].Page.Draft {
+			continue
+		}
+		b, e := m.content(m.files[i], r)
+		if e != nil {
+			m.errors(e)
+			continue
+		}
+		var h []string
+		for _, s := range headings.FindAllSubmatch(b, -1) {
+			h = append(h, plain(string(s[3])))
+		}
+		body := plain(string(b))
+		n := len(x.Pages)
+		seen := make(map[string]bool)
+		for _, t := range terms(links[i].Title + " " + strings.Join(h, " ") + " " + body) {
+			if !seen[t] {
+				seen[t] = true
+				x.Terms[t] = append(x.Terms[t], n)
+			}
+		}
+		d := document{URL: links[i].Path}
+		if include["title"] {
+			d.Title = links[i].Title
+		}
+		if include["headings"] {
+			d.Headings = h
+		}
+		if include["body"] {
+			if r := []rune(body); m.SearchBody > 0 && len(r) > m.SearchBody {
+				body = string(r[:m.SearchBody])
+			}
+			d.Body = body
+		}
+		x.Pages = append(x.Pages, d)
+	}
+	return x
+}
+
+// This writes the search index to search.js at the root of the output, as a
+// script assigning the index to `window.smdSearch`, since browsers refuse to
+// fetch files from pages opened from disk but will still load a script.
+func (m *Markdown) search(links []Link, r Renderer) error {
+	if !m.Search {
+		return nil
+	}
+	x := m.index(links, r)
+	if e := mkdirall(m.Output, os.ModePerm); e != nil {
+		return e
+	}
+	out, e := create(filepath.Join(m.Output, "search.js"))
+	if e != nil {
+		return e
+	}
+	defer out.Close()
+	if _, e := out.Write([]byte("window.smdSearch = ")); e != nil {
+		return e
+	}
+	return json.NewEncoder(out).Encode(x)
+}
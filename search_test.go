@@ -0,0 +1,62 @@
+package static
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownSearch(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{
+		"index.md":       "---\ntitle: Home\n---\n<h1>Welcome</h1><p>Install the tools &amp; go</p>",
+		"guide/setup.md": "<h2>Install</h2><p>Run setup</p>",
+		"draft.md":       "---\ndraft: true\n---\nsecret",
+	})
+	defer cleanup()
+
+	var rendered int
+	o := Operation(func(b []byte) []byte { rendered++; return b })
+	m := &Markdown{L: &mockLogger{}, Input: d, Web: true, Search: true, SearchFields: "title, body", SearchBody: 12}
+	if e := m.Run(o); e != nil {
+		t.Fatal(e)
+	}
+	var x index
+	b, _ := ioutil.ReadFile(filepath.Join(d, "public", "search.js"))
+	if !strings.HasPrefix(string(b), "window.smdSearch = ") {
+		t.Fatalf("expected the index to assign a global: %s", b)
+	}
+	if e := json.Unmarshal(b[len("window.smdSearch = "):], &x); e != nil {
+		t.Fatal(e)
+	}
+
+	// only the selected fields are stored, with the body truncated
+	expect := []document{{URL: "guide/setup.html", Title: "setup", Body: "Install Run "}, {URL: "index.html", Title: "Home", Body: "Welcome Inst"}}
+	if !reflect.DeepEqual(x.Pages, expect) {
+		t.Errorf("unexpected pages %#v", x.Pages)
+	}
+
+	// terms come from the full text, excluding drafts
+	if !reflect.DeepEqual(x.Terms["install"], []int{0, 1}) || !reflect.DeepEqual(x.Terms["go"], []int{1}) || x.Terms["secret"] != nil || x.Terms["the"] == nil {
+		t.Errorf("unexpected terms %#v", x.Terms)
+	}
+
+	// pages link to the index relative to themselves
+	if b, _ := ioutil.ReadFile(filepath.Join(d, "public", "guide", "setup.html")); !strings.Contains(string(b), `data-index="../search.js"`) {
+		t.Errorf("expected the search box in %s", b)
+	}
+
+	// each page is rendered once, and unmodified pages are indexed without
+	// being rendered again
+	if rendered != 3 {
+		t.Errorf("expected each page to be rendered once, got %d", rendered)
+	}
+	if e := m.Run(o); e != nil {
+		t.Fatal(e)
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(d, "public", "search.js")); rendered != 3 || !strings.Contains(string(b), "Welcome Inst") {
+		t.Errorf("expected the index from the previous build without rendering, got %d renders: %s", rendered, b)
+	}
+}
@@ -0,0 +1,180 @@
+package static
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+var listen = net.Listen
+var tempdir = ioutil.TempDir
+
+// The path used by browsers to listen for finished rebuilds.
+const reloadPath = "/_smd/reload"
+
+// A script injected into every html page that is served, which reloads the
+// page whenever the server reports that a rebuild has finished.
+const reloadScript = `<script>new EventSource("` + reloadPath + `").onmessage = function() { location.reload(); };</script>`
+
+// This is the preview server, which tracks every browser that is listening
+// for reloads.
+type server struct {
+	sync.Mutex
+	m       *Markdown
+	clients map[chan struct{}]bool
+}
+
+// This notifies every listening browser, without waiting on slow clients
+// since a single pending reload is all they need.
+func (s *server) reload() {
+	s.Lock()
+	defer s.Unlock()
+	for c := range s.clients {
+		select {
+		case c <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// This streams server-sent events to a browser until it disconnects.
+//
+// The client is registered before the headers are flushed, so once a browser
+// has a response it will not miss the next reload.
+func (s *server) events(w http.ResponseWriter, r *http.Request) {
+	f, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	c := make(chan struct{}, 1)
+	s.Lock()
+	s.clients[c] = true
+	s.Unlock()
+	defer func() {
+		s.Lock()
+		delete(s.clients, c)
+		s.Unlock()
+	}()
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	f.Flush()
+	for {
+		select {
+		case <-c:
+			fmt.Fprint(w, "data: reload\n\n")
+			f.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// This maps a url onto a file, matching the path mapping used by web mode,
+// so directories resolve to their index and the html extension is optional.
+//
+// In book mode the single output is served at the root, and anything else is
+// resolved against the input path so that relative images continue to work,
+// limited to the files web mode would copy so that hidden files such as
+// `.git` or `.env` are never served.
+func (s *server) resolve(p string) string {
+	p = filepath.FromSlash(path.Clean("/" + p))
+	var file string
+	if !s.m.Web {
+		if p == string(filepath.Separator) {
+			return s.m.Output
+		}
+		if file = filepath.Join(s.m.Input, p); !s.m.include(file) {
+			return ""
+		}
+	} else {
+		file = filepath.Join(s.m.Output, p)
+	}
+	if f, e := stat(file); e == nil && f.IsDir() {
+		file = filepath.Join(file, "index.html")
+	} else if e != nil && s.m.Web && filepath.Ext(file) == "" {
+		file += ".html"
+	}
+	if f, e := stat(file); e != nil || f.IsDir() {
+		return ""
+	}
+	return file
+}
+
+// This serves the reload events and the generated files, injecting the
+// reload script into html before the closing body tag.
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == reloadPath {
+		s.events(w, r)
+		return
+	}
+	file := s.resolve(r.URL.Path)
+	if file == "" {
+		http.NotFound(w, r)
+		return
+	}
+	if filepath.Ext(file) != ".html" {
+		http.ServeFile(w, r, file)
+		return
+	}
+	b, e := ioutil.ReadFile(file)
+	if e != nil {
+		http.Error(w, e.Error(), http.StatusInternalServerError)
+		return
+	}
+	if i := bytes.LastIndex(b, []byte("</body>")); i >= 0 {
+		b = append(b[:i], append([]byte(reloadScript), b[i:]...)...)
+	} else {
+		b = append(b, reloadScript...)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(b)
+}
+
+// This redirects the output into a temporary directory, so that previews
+// never touch the real output.
+func (m *Markdown) preview() (string, error) {
+	d, e := tempdir(os.TempDir(), "smd-serve")
+	if e != nil {
+		return "", e
+	}
+	if m.Web || m.Man {
+		m.Output = d
+	} else {
+		m.Output = filepath.Join(d, m.Title+m.extension())
+	}
+	return d, nil
+}
+
+// This starts the preview server on the configured host and port, defaulting
+// to 127.0.0.1 and 8080, and registers it to be notified after each rebuild.
+// The preview is only reachable from this machine unless another Host, such
+// as `0.0.0.0`, is supplied.
+//
+// The listener is opened immediately so that a port that is already in use
+// is reported before we start watching for changes.
+func (m *Markdown) serve() (net.Listener, error) {
+	if m.Host == "" {
+		m.Host = "127.0.0.1"
+	}
+	if m.Port == 0 {
+		m.Port = 8080
+	}
+	l, e := listen("tcp", net.JoinHostPort(m.Host, strconv.Itoa(m.Port)))
+	if e != nil {
+		return nil, e
+	}
+	s := &server{m: m, clients: make(map[chan struct{}]bool)}
+	m.reload = s.reload
+	go http.Serve(l, s)
+	m.L.Info("Serving %s on http://%s/", m.Output, net.JoinHostPort(m.Host, strconv.Itoa(m.Port)))
+	return l, nil
+}
@@ -0,0 +1,109 @@
+package static
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarkdownServe(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{"page.md": "before"})
+	defer cleanup()
+	file := filepath.Join(d, "page.md")
+
+	// listen on any free port and control each poll
+	var addr, bound string
+	listen = func(n, a string) (net.Listener, error) {
+		bound = a
+		l, e := net.Listen(n, "127.0.0.1:0")
+		if e == nil {
+			addr = "http://" + l.Addr().String()
+		}
+		return l, e
+	}
+	c := make(chan time.Time)
+	tick = func(time.Duration) <-chan time.Time { return c }
+	m := &Markdown{L: &mockLogger{}, Input: d, Web: true, Serve: true}
+	done := make(chan error)
+	go func() { done <- m.Run(Operation(func(b []byte) []byte { return b })) }()
+	c <- time.Now()
+
+	// the server only listens on the loopback address by default
+	if bound != "127.0.0.1:8080" {
+		t.Errorf("expected to listen on loopback, got %s", bound)
+	}
+
+	// pages resolve with or without the html extension and carry the script
+	for _, p := range []string{"/page.html", "/page"} {
+		r, e := http.Get(addr + p)
+		if e != nil {
+			t.Fatal(e)
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		if !strings.Contains(string(b), "before") || !strings.Contains(string(b), reloadPath) {
+			t.Errorf("unexpected page at %s: %s", p, b)
+		}
+	}
+	if r, e := http.Get(addr + "/missing"); e != nil || r.StatusCode != http.StatusNotFound {
+		t.Errorf("expected missing page to 404: %v", e)
+	}
+
+	// a rebuild pushes a reload event
+	r, e := http.Get(addr + reloadPath)
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer r.Body.Close()
+	ioutil.WriteFile(file, []byte("after"), 0644)
+	os.Chtimes(file, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+	c <- time.Now()
+	c <- time.Now()
+	if l, e := bufio.NewReader(r.Body).ReadString('\n'); e != nil || !strings.HasPrefix(l, "data:") {
+		t.Errorf("expected reload event, got %q: %v", l, e)
+	}
+	close(c)
+	if e := <-done; e != nil {
+		t.Error(e)
+	}
+}
+
+func TestServerResolve(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{
+		".git/config": "secret",
+		".env":        "secret",
+		"image.png":   "png",
+	})
+	defer cleanup()
+
+	// book mode serves assets from the input, but never hidden files
+	s := &server{m: &Markdown{Input: d, Output: filepath.Join(d, "book.html")}}
+	if f := s.resolve("/image.png"); f != filepath.Join(d, "image.png") {
+		t.Errorf("expected image to resolve, got %q", f)
+	}
+	for _, p := range []string{"/.git/config", "/.env", "/../" + filepath.Base(d) + "/.env"} {
+		if f := s.resolve(p); f != "" {
+			t.Errorf("expected %s to be hidden, got %q", p, f)
+		}
+	}
+
+	// a relative input still hides files starting with a dot
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(d)
+	s = &server{m: &Markdown{Input: ".", Output: "book.html"}}
+	if f := s.resolve("/image.png"); f != "image.png" {
+		t.Errorf("expected image to resolve, got %q", f)
+	}
+	for _, p := range []string{"/.git/config", "/.env"} {
+		if f := s.resolve(p); f != "" {
+			t.Errorf("expected %s to be hidden, got %q", p, f)
+		}
+	}
+}
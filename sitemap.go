@@ -0,0 +1,81 @@
+package static
+
+import (
+	"encoding/xml"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// The sitemap protocol namespace.
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type urlset struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// This builds the absolute url for a path relative to the output.
+func (m *Markdown) absolute(p string) string {
+	return strings.TrimSuffix(m.BaseURL, "/") + "/" + (&url.URL{Path: p}).EscapedPath()
+}
+
+// Pages are excluded from the sitemap when they are drafts, or when their
+// front matter sets `sitemap: false`.
+func excluded(l Link) bool {
+	s, ok := l.Page.Params["sitemap"].(bool)
+	return l.Page.Draft || (ok && !s)
+}
+
+// This writes a sitemap listing every page at the root of the output, using
+// the date of each page as the last modified time.
+//
+// A sitemap requires absolute urls, so nothing is written without a BaseURL.
+func (m *Markdown) sitemap(links []Link) error {
+	if m.BaseURL == "" {
+		return nil
+	}
+	s := urlset{Xmlns: sitemapNamespace}
+	for _, l := range links {
+		if excluded(l) {
+			continue
+		}
+		u := sitemapURL{Loc: m.absolute(l.Path)}
+		if !l.Date.IsZero() {
+			u.LastMod = l.Date.UTC().Format(time.RFC3339)
+		}
+		s.URLs = append(s.URLs, u)
+	}
+	return m.xml("sitemap.xml", s)
+}
+
+// This writes a value as an xml document to the root of the output.
+func (m *Markdown) xml(name string, v interface{}) error {
+	if e := mkdirall(m.Output, os.ModePerm); e != nil {
+		return e
+	}
+	out, e := create(filepath.Join(m.Output, name))
+	if e != nil {
+		return e
+	}
+	defer out.Close()
+	if _, e := io.WriteString(out, xml.Header); e != nil {
+		return e
+	}
+	enc := xml.NewEncoder(out)
+	enc.Indent("", "\t")
+	if e := enc.Encode(v); e != nil {
+		return e
+	}
+	_, e = io.WriteString(out, "\n")
+	return e
+}
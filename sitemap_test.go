@@ -0,0 +1,44 @@
+package static
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarkdownSitemap(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{
+		"index.md":          "---\ndate: 2017-04-13\n---\nhome",
+		"guide/my setup.md": "setup",
+		"draft.md":          "---\ndraft: true\n---\ndraft",
+		"hidden.md":         "---\nsitemap: false\n---\nhidden",
+	})
+	defer cleanup()
+	mod := time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)
+	os.Chtimes(filepath.Join(d, "guide", "my setup.md"), mod, mod)
+
+	m := &Markdown{L: &mockLogger{}, Input: d, Web: true, BaseURL: "https://example.com/docs/"}
+	if e := m.Run(Operation(func(b []byte) []byte { return b })); e != nil {
+		t.Error(e)
+	}
+	b, e := ioutil.ReadFile(filepath.Join(d, "public", "sitemap.xml"))
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	// urls are absolute and escaped, with dates from front matter or the file
+	for _, s := range []string{
+		"<loc>https://example.com/docs/guide/my%20setup.html</loc>\n\t\t<lastmod>2017-01-02T03:04:05Z</lastmod>",
+		"<loc>https://example.com/docs/index.html</loc>\n\t\t<lastmod>2017-04-13T00:00:00Z</lastmod>",
+	} {
+		if !strings.Contains(string(b), s) {
+			t.Errorf("expected %s in %s", s, b)
+		}
+	}
+	if strings.Contains(string(b), "draft") || strings.Contains(string(b), "hidden") {
+		t.Errorf("expected excluded pages to be omitted: %s", b)
+	}
+}
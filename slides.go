@@ -0,0 +1,107 @@
+package static
+
+import (
+	"bytes"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var rules = regexp.MustCompile(`(?i)<hr\s*/?>`)
+var notes = regexp.MustCompile(`(?i)<p>\s*Note:\s*`)
+
+// A single slide in a presentation, numbered from one, with any speaker notes
+// separated from the content.
+type Slide struct {
+	Number  int
+	Content template.HTML
+	Notes   template.HTML
+}
+
+// This splits the html for a single file into a deck of slides on every
+// horizontal rule, ignoring slides that are empty.
+//
+// A paragraph starting with `Note:` begins the speaker notes, which run to the
+// end of the slide.
+func deck(b []byte) [][2][]byte {
+	var l [][2][]byte
+	for _, s := range rules.Split(string(b), -1) {
+		c, n := []byte(s), []byte(nil)
+		if i := notes.FindIndex(c); i != nil {
+			c, n = c[:i[0]], append([]byte("<p>"), c[i[1]:]...)
+		}
+		if len(bytes.TrimSpace(c)) == 0 && len(n) == 0 {
+			continue
+		}
+		l = append(l, [2][]byte{bytes.TrimSpace(c), bytes.TrimSpace(n)})
+	}
+	return l
+}
+
+// This produces a single html presentation from every file in order, where
+// each file is split into slides on horizontal rules.
+//
+// It shares the book mode pipeline, so each file has any front matter removed
+// and headings given anchors that are unique across the presentation, and
+// assets are inlined when self contained.  Unlike a book the slides are held
+// in memory, since the template ranges over them, and links between files are
+// left as they are.
+func (m *Markdown) slides(r Renderer) error {
+	t, e := m.template(r)
+	if e != nil {
+		return e
+	}
+	if t, e = m.layout(t, m.mode(), r, ""); e != nil {
+		return e
+	}
+	a := &anchors{}
+	var slides []Slide
+	for i := range m.files {
+		in, e := open(m.files[i])
+		if e != nil {
+			m.errors(e)
+			continue
+		}
+		d, e := readall(in)
+		m.errors(in.Close())
+		if e != nil {
+			m.errors(e)
+			continue
+		}
+		if _, d, e = m.render(r, m.files[i], d); e != nil {
+			m.errors(e)
+			continue
+		}
+		d = a.anchor(d)
+		if m.SelfContained {
+			d = m.inline(filepath.Dir(m.files[i]), d)
+		}
+		for _, s := range deck(d) {
+			slides = append(slides, Slide{Number: len(slides) + 1, Content: template.HTML(s[0]), Notes: template.HTML(s[1])})
+		}
+	}
+
+	var b bytes.Buffer
+	if e := t.Execute(&b, struct {
+		Title   string
+		Version string
+		Slides  []Slide
+	}{
+		Title:   m.Title,
+		Version: m.Version,
+		Slides:  slides,
+	}); e != nil {
+		return e
+	}
+	if e := mkdirall(filepath.Dir(m.Output), os.ModePerm); e != nil {
+		return e
+	}
+	out, e := create(m.Output)
+	if e != nil {
+		return e
+	}
+	defer out.Close()
+	_, e = out.Write(m.contain(b.Bytes()))
+	return e
+}
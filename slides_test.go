@@ -0,0 +1,52 @@
+package static
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDeck(t *testing.T) {
+	in := "<p>one</p>\n<hr>\n<p>two</p>\n<p>Note: say <em>hi</em></p>\n<HR />\n\n<hr/>\n<p>three</p>"
+	l := deck([]byte(in))
+	if len(l) != 3 {
+		t.Fatalf("expected 3 slides, got %d: %q", len(l), l)
+	}
+	if string(l[0][0]) != "<p>one</p>" || len(l[0][1]) != 0 {
+		t.Errorf("unexpected first slide: %q", l[0])
+	}
+	if string(l[1][0]) != "<p>two</p>" || string(l[1][1]) != "<p>say <em>hi</em></p>" {
+		t.Errorf("unexpected notes: %q", l[1])
+	}
+	if string(l[2][0]) != "<p>three</p>" {
+		t.Errorf("unexpected last slide: %q", l[2])
+	}
+}
+
+func TestMarkdownSlides(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{
+		"1.md": "---\ntitle: Intro\n---\n<h1>Intro</h1>\n<hr>\n<p>two</p>\n<p>Note: remember</p>",
+		"2.md": "<h1>Intro</h1>",
+		"3.md": "---\n<h1>Rule</h1>\n\n---\n\n<h1>Second</h1>\n",
+	})
+	defer cleanup()
+
+	// slides continue across files, with unique anchors and notes kept apart,
+	// and a deck opening with a rule keeps its first slide
+	out := filepath.Join(d, "talk.html")
+	m := &Markdown{L: &mockLogger{}, Input: d, Output: out, Title: "Talk", Slides: true}
+	if e := m.Run(Operation(func(b []byte) []byte { return bytes.Replace(b, []byte("---"), []byte("<hr>"), -1) })); e != nil {
+		t.Fatal(e)
+	}
+	b, _ := ioutil.ReadFile(out)
+	for _, s := range []string{`<title>Talk</title>`, `id="slide-1"`, `id="slide-5"`, `<aside class="notes"><p>remember</p></aside>`, `id="intro-1"`, `>Rule</h1>`} {
+		if !strings.Contains(string(b), s) {
+			t.Errorf("expected %s in presentation", s)
+		}
+	}
+	if strings.Contains(string(b), `id="slide-6"`) || strings.Contains(string(b), "title: Intro") {
+		t.Errorf("unexpected presentation:\n%s", b)
+	}
+}
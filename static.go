@@ -6,7 +6,24 @@
 //
 // Template parameters are simple, and include Title, Content, and Version;
 // both the Version and Title can be changed.  If in web mode, an additional
-// property called Name will be set to the basename of the file.
+// property called Name will be set to the basename of the file, Page will
+// hold the metadata from the front matter of the file, and both Pages and Tree
+// will describe every page in the site for building navigation, and Search
+// will hold the url of the search index relative to the page when one is
+// written.  In book mode TOC holds the nested headings from every file, each
+// with a unique anchor.
+//
+// Every template may also call relURL, absURL, dateFormat, slugify,
+// markdownify, plainify, truncate, default, dict, slice, readFile and getenv,
+// which are described in the readme.
+//
+// Front matter is optional, and may be yaml fenced by `---`, toml fenced by
+// `+++`, or a json object at the start of the file.  It is always removed
+// before the markdown is processed.
+//
+// Markdown is converted by a Renderer, which receives the front matter along
+// with the path of each file, and may report errors or return data extracted
+// while rendering.  Operation adapts a plain function to the interface.
 package static
 
 // List of extensions matching the github parser, but with an inversed order
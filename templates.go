@@ -1,6 +1,7 @@
 // Code generated by go-bindata.
 // sources:
 // templates/book.tmpl
+// templates/slides.tmpl
 // templates/web.tmpl
 // DO NOT EDIT!
 
@@ -69,7 +70,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesBookTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x58\xdd\x6f\xdb\x38\x12\x7f\x76\xff\x8a\x59\x15\x0b\x38\x08\xe5\xf8\xb3\x1f\xb2\x1d\x5c\x6f\x71\x0f\x07\x1c\xf6\xe5\x16\xf7\x52\xf4\x00\x5a\x1c\x59\xbc\x52\xa4\x96\xa2\x9d\xe4\x8c\xfc\xef\x0b\x52\xa4\x44\xc9\x69\x9b\xa2\x80\x23\x91\xf3\xf1\x9b\xe1\xfc\x66\xc4\xee\x7e\x61\x2a\x37\x4f\x35\x42\x69\x2a\x71\xff\x66\x67\xff\x80\xa0\xf2\xb8\x4f\x50\x26\xf7\x6f\x26\xbb\x12\x29\xbb\x7f\x33\x99\xec\x2a\x34\x14\xf2\x92\xea\x06\xcd\x3e\x39\x99\x22\xfd\x90\xb8\x0d\xc3\x8d\xc0\xfb\xcb\x65\xf6\x87\x7d\x78\x7e\xde\xdd\xb5\x2b\x76\xaf\x31\x4f\xed\xd3\xc4\x5a\x26\x70\x50\xec\x89\x00\xe3\x67\x02\x4d\x4d\x25\x01\x75\xf8\x1f\xe6\x86\x40\xb9\x20\x50\x2e\x09\x94\x2b\x02\xe5\x9a\x40\xb9\x21\x4e\xeb\x1d\x81\x9a\xc0\x41\xa8\xfc\xeb\x9f\x27\x65\x90\x40\xad\x91\x00\x25\x90\x2b\x86\x04\xb0\x22\xc0\xab\x23\x81\xc6\x68\x25\x8f\x4e\x89\x09\x02\x4a\x10\x38\x09\x02\x82\x5b\x99\x03\x32\x02\x85\x52\x06\x35\x01\x1b\x91\xfd\x5b\xa1\x3c\x11\x90\xf4\xec\x94\x0c\xaf\xac\xdd\x13\xe3\x8a\xc0\x99\x33\x54\xd6\x95\x3a\x6a\x6c\x1a\xb8\x58\x89\x49\x45\xf5\x91\xcb\x0c\xe6\x5b\xf7\x5a\x53\xc6\xb8\x3c\x76\xef\x07\xa5\x19\xea\xee\xb5\x50\xd2\x64\xc0\x65\x89\x9a\x9b\x76\xe9\x8c\xda\xf0\x9c\x8a\x94\x0a\x7e\x94\x19\x1c\x68\x83\x82\x4b\x74\xbb\xcf\x6f\x9c\xd2\x35\x46\xb8\x00\xe3\x4d\x2d\xe8\x53\xd6\x26\x62\x0b\x4e\xd6\x63\xcd\xa9\x3c\xd3\xa6\x07\xeb\xd1\x7b\xcc\x9d\x26\x97\xd6\x53\xda\x1a\x78\x25\x1a\x57\x0d\x17\xb0\x91\xa4\x05\xad\xb8\x78\xca\xa0\x44\x71\x46\xab\xb7\x6d\xd7\x1b\xfe\x7f\xcc\x60\xb9\xac\x1f\x3d\x2c\x7b\xc4\xde\xb9\xf3\x58\x22\x3f\x96\x26\x83\xc5\x6c\xdd\xfa\xcd\x95\x50\x3a\x83\xb7\xab\xd5\xca\x27\x8e\xe6\x5f\x8f\x5a\x9d\x24\x4b\xc3\x5e\x51\x14\x11\x8c\x45\x00\xd1\x3a\x5b\xcc\xde\x6f\xb0\xf2\xee\xca\xe5\x78\x33\xda\x5b\x8d\xf7\x96\xd1\xe6\x75\xc5\x41\xf9\xce\x23\x1f\x84\xdc\x50\xd9\xa4\x0d\x6a\x5e\x10\x38\xa2\xd2\x47\x4e\xb7\x51\x41\xa4\x46\xd5\x19\x2c\xe7\xf5\xe3\x60\xf5\xa0\x8c\x51\x55\x06\x8b\x65\xd8\x10\x68\x0c\xea\xb4\xa9\x69\xee\xea\x26\x6c\x78\xac\x16\x06\x5c\x20\x36\xba\xd8\xd8\xb4\x8e\x0d\xce\xbb\x5c\x07\xcc\x43\xa5\x68\xdf\xc5\x18\x92\xf0\xe0\x4f\xe2\xa0\x04\x43\xdd\xe5\xa8\x0d\xdf\xf3\x07\x0e\x2f\x49\x7b\x59\xac\xba\x7c\x5a\x5e\x67\xc0\x0d\x15\x3c\xdf\x0e\x15\x96\xf3\x79\x28\x85\x8e\xb6\x3f\x4a\x6b\x5f\x55\x4e\xce\xe9\x34\x19\x48\xe5\xcb\xb1\x27\x9b\x8d\xee\x2a\xd9\x19\xcc\x61\x1e\xad\xb6\x54\x4c\x05\x16\x26\x83\x4d\xfd\x08\x8d\x12\x9c\xc1\x5b\x44\xec\xc9\x39\x0a\xa2\x3f\x09\xed\xd1\x86\xca\x9d\x0f\x5d\x59\x37\xdf\xe0\xbc\xf7\xdb\x1e\xc3\xb5\x5b\x67\xbe\xd6\x38\xe6\x54\xa5\xa4\xb2\x55\x81\x3e\x6f\xb6\xb5\x79\x0c\x5d\xdc\xcb\xfa\x11\xd6\x21\xbe\xa8\xa6\xe7\xb3\x8f\x58\x8d\x1b\xd2\x6c\xbe\xc1\xaa\x17\xf7\xb0\x34\x65\xfc\xd4\x64\xd1\x7a\x47\xbc\x0c\xde\xce\xe7\x3e\x08\x75\x46\x5d\x08\xf5\x90\x3e\x66\x40\x4f\x46\x0d\x59\x5b\x7c\xb0\xff\x86\xe1\x44\x78\x47\x9d\x6a\x90\xb8\x45\x94\xb8\x08\xeb\xc6\xe3\x77\xe6\x4e\xb6\xe1\x08\xde\x74\xa7\xc3\x78\x93\xfb\xb4\xa8\xab\x3d\xcc\x79\x45\x85\xdf\x16\x7c\xd0\xa7\xfd\xe9\xaf\x37\xdf\xe0\x65\xb7\x3e\xea\x52\xcb\x4d\x8c\xa6\x9d\x24\x76\xa2\xd4\x3d\xcd\x5e\x60\x22\x25\x40\xb3\x33\x6f\xb8\x41\x06\x17\xe8\x7b\xdc\x7b\x7a\x78\x1f\x64\xb2\xd2\xe6\xd6\x4a\xd2\xdc\xf0\x73\xc8\xd9\x75\xff\x33\x9a\xca\xa6\xa6\x1a\xa5\x9f\x1c\x06\x1f\x4d\xca\x30\x57\x9a\x1a\xae\x64\x06\x27\xc9\x50\x77\xdd\x7a\xa2\x4e\xc6\xbe\x74\x85\xe8\xed\xc4\xd3\x67\x0c\xa1\x50\xf9\x29\x4c\xb6\x1f\x99\x0f\xe1\x2c\x57\x9b\xe5\xfb\x3c\x36\x17\x5b\xe9\x40\x98\x92\x4b\x60\xca\x18\x64\x3f\x05\x3f\x55\x45\xd1\xa0\xc9\x20\x1d\xb6\xc6\x85\x9d\xf7\xf6\x37\x64\xd8\x75\x35\xda\xfe\x46\x6b\x2b\xb7\xb6\x8a\xd6\x9c\xfa\xda\x2d\xaf\x63\xd1\xb6\xdb\xd9\xf5\xf0\xd4\x1f\xde\x20\xe2\xf5\x7a\xfd\xaa\x10\x1c\x50\x5e\x1d\xbf\x43\x83\xd0\x2b\xfa\xa6\x56\xd1\xc7\xf4\x81\x33\x53\xda\x52\x9a\xff\xba\x1d\x74\x9d\x9e\x7a\xe3\x41\x5d\x71\xc6\x44\x3c\xa6\xfd\xc7\xc2\x2c\x57\xd2\xa0\x34\xe1\x53\xa7\xa3\x43\xe7\xe5\xe3\x87\xab\xc6\xe9\xba\x59\xef\xab\xe7\x25\x2c\x7f\x1d\xbb\xb8\xa7\xa3\xec\x74\x3d\xe3\x2a\x3b\x5d\x90\x91\x3a\xb8\x41\x3e\xe6\xd0\xa6\xe3\x90\xa4\x67\x70\xfc\x1f\x10\x78\x79\x25\xd0\x11\xfd\x6a\xec\x5f\xf3\x79\x79\x15\xd6\x62\x13\xd7\x56\xc8\x14\xb8\x08\x7c\x82\x73\x94\xa6\x1b\x90\x33\x4b\xcd\x3a\xa3\x45\x9f\x51\x9f\xe7\x0c\x92\x64\x3b\x3c\x6e\x43\x0f\x22\x70\x46\x20\xd5\x76\x7c\x9a\xb2\x77\xf7\xb7\x0a\x19\xa7\xa0\xa4\x78\x82\x26\xd7\x88\x12\xa8\x64\x30\xad\xb8\xec\xcf\x68\x51\x3f\xde\x84\x11\x14\x7d\x81\xf9\x40\x3f\x76\xe9\x78\xad\xc5\xc5\x72\x3e\xff\xae\xc9\x77\x9d\xc9\xb6\x8f\x8d\xda\x14\x19\x90\x6c\x11\x88\xd7\x11\x6e\x1d\xf3\xc8\x7b\xf9\x4e\x45\xb4\xb8\x07\x35\x11\x81\x59\x75\x1f\x68\x71\x78\xb5\xe6\xd2\x84\x91\xd8\x7e\xd9\x2b\xf7\x75\x0f\x17\x50\xba\x2e\xa9\x6c\x32\xd8\x6c\xe1\x81\x33\xf5\xd0\x64\xb0\x0a\xf1\x04\xc9\xab\x1b\x44\x34\xb3\x26\x35\x3d\x62\x7a\xd0\x48\xbf\xa6\x5c\x36\x9c\x61\x06\xf4\xac\x38\x1b\xc0\xed\x3e\x15\xe1\x02\x91\x82\xab\x8b\x20\xef\x9d\xbe\x90\xe2\x2e\x2a\xff\x7d\x0c\xf1\xec\x7d\x28\xb9\x09\xb3\x7f\x12\x58\x3c\x92\x89\x47\x82\x97\xac\x81\x7e\x2e\x35\x16\xff\xdd\x27\xa5\x31\x75\xf2\xa5\x2d\x52\x97\x96\x17\x77\x42\xbc\x7d\xfd\xc2\x34\x01\x6a\x8c\x9e\x5a\xe9\x1b\x48\x6e\x92\x28\x66\xfb\xb3\xbb\x0b\x57\xb8\xdd\x9d\xbf\x0a\xee\x6c\x04\xee\x76\xe7\xbb\x82\x95\xdd\x95\x8b\xc1\x15\xb0\x5c\xb4\xcb\x92\x9e\xef\x77\x77\xf6\xd7\x19\x0b\x1a\xf6\x85\xf1\x33\xe4\x82\x36\xcd\x3e\xf1\x80\x12\x6b\xe2\xb7\xf6\xd9\x1a\x61\xfc\xdc\x8a\xb6\x2c\x6d\x2d\xd6\xf7\x97\x0b\x2f\x60\xf6\x1f\xd4\x0d\x57\xf2\xf9\xf9\x72\x89\x9f\x51\x32\xab\x5a\xb7\xc2\x91\x8f\x26\xd7\xbc\x36\x8d\xbb\xb3\xda\x9b\xa9\x7b\x6d\x5f\x26\xd3\xe2\x24\x73\x5b\xa6\xd3\xc0\x92\xc9\xe4\x4c\xb5\xbd\x1c\xc2\x1e\x98\xca\x4f\x15\x4a\x33\xfb\xf3\x84\xfa\xe9\xdf\x28\x30\x37\x4a\x4f\x13\x49\xcf\xc9\xcd\xd6\x8b\xf3\x02\xa6\xf6\x26\xad\x8a\x56\x6b\xbf\x87\xc4\x8e\x88\x82\x4b\x64\x49\x6f\x76\xa2\xd1\x9c\xb4\xf4\x6f\xcf\x91\xb3\x36\x37\xcd\x37\x1d\x7e\x12\x62\x9a\x94\x0b\x52\x2e\x49\xb9\x22\xe5\x9a\x94\x1b\x52\xbe\x1b\x22\xf0\x36\x66\x02\xe5\xd1\x94\x0e\xc5\xfc\x35\xbe\x19\xd6\x56\x1c\x16\xc4\x7d\x60\xc5\x18\x72\x8d\xd4\xe0\x3f\x04\xda\xb7\x69\x72\x12\xbd\x47\xab\xc9\x09\x08\xd8\xc3\xd0\x71\x10\x28\x94\x86\x29\x87\x3d\xcc\xb7\xc0\x61\x07\x62\x0b\xfc\xf6\x36\x02\x64\x2d\x50\x99\x97\x4a\x7f\x62\xcc\x5d\xb5\xf7\x60\xf3\xca\x8f\xae\x6b\xa4\x09\xdc\x02\xdf\x5e\x4b\x7f\x07\x20\xed\xf1\x4d\x5a\xe1\x59\x83\xe6\x93\x31\x9a\x1f\x4e\x06\xed\xb1\x55\x98\x90\xa1\xdb\x5e\xc5\x07\xf2\x99\x7f\x99\xb5\x6c\xfb\x5d\x31\x9c\x71\xd9\xa0\x36\x7f\xc7\x42\x69\x9c\xb6\x9a\xe1\x92\x6e\x45\x6f\x06\x10\x25\x3d\xff\x4e\x2b\xec\xb3\x62\x8d\x71\x29\x51\xff\x81\x8f\x66\x20\x6a\x93\xfd\x4f\x83\xd5\x77\xe2\x11\x3c\xb9\x79\x51\xe7\x5f\x5c\x7e\x7d\x65\x1e\x62\x95\x19\xad\x6b\x94\xec\xb7\x92\x0b\x36\x1d\x29\x5b\x7c\x36\xde\xa9\x0f\xe1\xe6\x1b\x26\x86\x09\xb5\xbd\x23\x21\x90\xbc\x4d\x6e\xbf\x91\xd4\xa0\x3c\xf0\x1d\x5b\x1c\x46\x68\xe4\x30\x77\x86\x1e\x2d\x9a\x91\x8c\x2d\x3b\x23\x3f\x1b\xe9\x8b\x2e\x5d\x7c\xe9\x24\x1c\x1b\xad\xc4\xbe\x2d\xed\xa8\xe4\x1c\x98\x17\x81\xf4\x20\x9e\x01\x45\x83\x10\xac\xdc\x5f\x1b\x61\x2a\x7a\x69\x4f\x5d\xbc\x92\x35\x2f\x41\x90\x62\xbc\x0d\x7b\x90\x22\x5a\x73\x08\x6e\x6f\xfb\x95\x67\x3b\x36\x04\xc2\xb4\xe5\xee\x2f\x36\x19\xb1\x95\x9f\x0f\x73\x77\x1d\xe6\xc0\xc5\xbd\xf3\x10\xc7\xed\x81\x3a\x57\x3d\x5b\xc6\xa8\xd3\x34\x42\xfd\x53\xf8\x46\x9d\x6a\x84\x66\x11\x61\xf9\x01\x92\x11\x8e\x60\x50\xd2\xf3\x15\x84\xe0\xfe\xf9\x66\x7a\xd3\x8e\x8a\xbb\x68\x56\xf8\xa1\x64\x1f\xba\xa1\xb4\xbb\x6b\x27\xe2\xee\xce\xfd\x2f\xea\x5f\x01\x00\x00\xff\xff\xe5\x03\x78\x3b\x55\x15\x00\x00")
+var _templatesBookTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x57\x5f\x8f\xa3\xba\x15\x7f\xce\x7e\x8a\xd3\x8c\xae\xb4\x2b\x41\x06\x48\x32\xd9\x21\x4c\x54\x69\xfb\xd2\xa7\xbe\x5c\xf5\xa5\x6a\x25\x07\x1f\x82\xef\x18\x9b\x1a\x93\xc9\x14\xe5\xbb\x57\x06\x1b\x4c\x32\xfb\xe7\x6a\xa4\x09\xf8\xfc\xff\xf9\x9c\x9f\x4d\xf6\x17\x2a\x73\xfd\x5e\x23\x94\xba\xe2\x87\x4f\x99\xf9\x01\x4e\xc4\xe9\x65\x89\x62\x79\xf8\xb4\xc8\x4a\x24\xf4\xf0\x69\xb1\xc8\x2a\xd4\x04\xf2\x92\xa8\x06\xf5\xcb\xb2\xd5\x45\xf8\xd5\x28\x2c\x32\xcd\x34\xc7\x43\xd7\xad\x7e\x37\x0f\xd7\x6b\xf6\x38\xac\x18\x59\xa3\xdf\x87\xa7\x85\xf1\x1c\xc0\x51\xd2\xf7\x00\x28\x3b\x07\xd0\xd4\x44\x04\x20\x8f\x7f\x60\xae\x03\x28\xe3\x00\xca\x24\x80\x72\x1d\x40\xb9\x09\xa0\xdc\x06\xbd\xd5\x53\x00\x75\x00\x47\x2e\xf3\xd7\xff\xb6\x52\x63\x00\xb5\xc2\x00\x48\x00\xb9\xa4\x18\x00\x56\x01\xb0\xea\x14\x40\xa3\x95\x14\xa7\xde\x88\xf2\x00\x24\x0f\xa0\xe5\x01\x70\x66\x74\x8e\x48\x03\x28\xa4\xd4\xa8\x02\x30\x15\x99\xdf\x0a\x45\x1b\x80\x20\xe7\xde\x48\xb3\xca\xf8\x6d\x29\x93\x01\x9c\x19\x45\x69\x42\xc9\x93\xc2\xa6\x81\xce\x68\x2c\x2a\xa2\x4e\x4c\xa4\x10\xed\xfb\xd7\x9a\x50\xca\xc4\x69\x7c\x3f\x4a\x45\x51\x8d\xaf\x85\x14\x3a\x05\x26\x4a\x54\x4c\x0f\x16\x67\x54\x9a\xe5\x84\x87\x84\xb3\x93\x48\xe1\x48\x1a\xe4\x4c\x60\x2f\xbd\x9a\x7f\x1f\xe5\x08\x1d\x50\xd6\xd4\x9c\xbc\xa7\x03\x10\x7b\xe8\x75\x6d\xae\x39\x11\x67\xd2\x4c\xc9\xda\xec\x6d\xce\xa3\x25\x13\x26\x52\x38\x38\xf8\xc5\x6c\xcc\x9e\x41\x07\xa6\x92\xb0\x20\x15\xe3\xef\x29\x94\xc8\xcf\x68\xec\xf6\xc3\x7a\xc3\xfe\x87\x29\x24\x49\x7d\xb1\x69\x99\x2d\xb6\xc1\xfb\x88\x25\xb2\x53\xa9\x53\x88\x57\x9b\xbe\xce\x45\x2e\xb9\x54\x29\x3c\xac\xd7\x6b\x0b\x1c\xc9\x5f\x4f\x4a\xb6\x82\x86\x4e\x56\x14\x85\x97\x46\xec\x92\x18\x82\xc5\xab\xdd\x16\x2b\x1b\xae\x4c\x6e\x85\x9e\x6c\x7d\x2b\x4b\x3c\xe1\x7d\xc7\x41\xf9\x64\x33\x9f\x95\xdc\x10\xd1\x84\x0d\x2a\x56\x04\x70\x42\xa9\x4e\x8c\xec\xbd\x86\x08\xb5\xac\x53\x48\xa2\xfa\x32\x5b\x3d\x4a\xad\x65\x95\x42\x9c\x38\x01\x47\xad\x51\x85\x4d\x4d\xf2\xbe\x6f\x9c\xc0\xe6\x6a\x1a\x1f\x3a\xf0\x9d\xc6\x5b\x03\xeb\xad\xc3\x68\xc4\xda\xe5\x3c\x37\xf2\xe4\x7d\x8d\x0e\x84\x37\xbb\x13\x47\xc9\x29\x2a\xa7\x62\xcb\xb7\xf3\x03\xc7\x8f\xb4\xad\x2e\x56\x4e\xd8\xcf\x75\x0a\x4c\x13\xce\xf2\xfd\xdc\x20\x89\x22\xab\x3f\x8d\xed\xcf\x60\x9d\xba\xaa\xd7\xeb\x47\xbd\x49\x41\x48\xdb\x8e\xd3\xb0\xc5\x51\x7d\xb9\x03\x3b\x85\x08\x22\x6f\x75\x18\xc5\x90\x63\xa1\x53\xd8\xd6\x17\x68\x24\x67\x14\x1e\x10\xad\xbb\x0f\x8a\x98\x76\x42\xd9\x6c\x5d\xe7\x46\xf3\x50\x26\xcc\x77\x66\xde\xc6\x1d\xb6\xe1\x3e\x6c\xef\xbe\x56\xe8\x50\x74\x48\x54\x52\x48\xd3\x15\x68\x71\x33\xd4\x06\xdd\xbc\xee\xa4\xbe\xc0\xc6\xd5\xe7\xf5\x74\xb4\x7a\xc6\xea\x96\x90\x56\xd1\x16\x2b\xd8\xdc\xc0\xa1\x08\x65\x6d\x93\x7a\xeb\xe3\xe0\xa5\xf0\x10\x45\xb6\x08\x79\x46\x55\x70\xf9\x16\x5e\x52\x20\xad\x96\xf3\xa9\x2d\xbe\x9a\xbf\x79\x39\x5e\xbe\x37\x4c\x35\x03\x2e\xf6\x80\xf3\x72\xdd\xda\xfc\xfb\xd2\x57\x25\x0f\x5f\x03\x30\x3f\x14\x3a\x18\xa3\x3e\x27\x4f\xbb\xc4\xc2\x63\x84\x7a\xd0\xa9\x3c\x9d\xa7\x27\xfa\x8c\x85\xa7\xd3\x78\x42\x7c\xa2\xc7\xdd\xc6\x13\x0a\x4f\x48\xf0\x6b\x5c\xf8\x96\x64\xf0\xce\x7c\x9d\x27\x4c\x12\xb7\x41\x46\x78\xf6\x84\x05\x7d\xde\xc5\xbe\x83\xdc\x13\xee\xb6\xbb\x78\x8b\x7b\xf8\xa0\xe7\x06\x83\x96\x43\x07\x9c\x35\xa3\x94\xb2\xc6\xc9\xe4\x9d\x0c\x73\x56\x11\x6e\xc5\x9c\x59\xdc\x2d\x01\x0c\x1d\xbf\xd9\xba\x1d\xbe\xa1\x8e\x71\xfd\x86\x99\x93\xed\xb4\x03\xad\x3d\x3d\xcd\x29\x5a\x43\xf7\x03\xf6\x21\x01\x90\xf4\xcc\x1a\xa6\xd1\xdf\xab\xf5\x7a\x47\x8e\x3b\xa7\x93\x96\xa6\x9f\x8c\x26\xc9\x35\x3b\xbb\x3e\xb9\xe7\x7c\xad\x88\x68\x6a\xa2\x50\xd8\xd3\x52\xe3\x45\x87\x14\x73\xa9\x88\x66\x52\xa4\xd0\x0a\x8a\x6a\x3c\xa1\x16\xb2\xd5\xe6\x65\x1c\x3e\xeb\xc7\x3f\x71\x6f\x53\x28\x64\xde\xba\xd3\xfc\x67\xee\xad\xbb\x87\x64\xbd\x4d\x76\x1e\x3d\xcc\xbd\x8c\x49\xe8\x92\x09\xa0\x52\x6b\xa4\x7f\x2a\xfd\x50\x16\x45\x83\x3a\x85\x70\x7e\x1c\xc4\xe6\x8e\x53\xc6\x13\xc2\x3d\x93\x9b\xb5\x64\xb6\xb6\xee\xf5\xd6\xde\x9a\xf1\x51\x6e\xfa\xe5\x8d\xaf\x3a\x30\x3c\x90\xe9\xc9\xc9\xa0\x9b\x55\xbc\xd9\x6c\x7e\xa9\x84\x3e\x51\x56\x9d\x7e\x30\xfa\x8e\x1f\x27\x22\xaf\xc8\x25\x7c\x63\x54\x97\xa6\x95\xa2\xdf\xf6\x33\xa6\x9d\xe8\xe6\xf6\x72\x52\x31\x4a\xb9\x17\xd6\x5d\x90\x56\xb9\x14\x1a\x85\x76\xd7\x3b\xe8\x6e\xa3\x3c\x7f\xbd\x3b\x2c\x12\x43\x44\x53\xac\x89\x8b\x20\xf9\xed\x36\xc4\x81\xdc\xa0\x33\xf2\xe4\x1d\x3a\x63\x91\x9e\x39\x94\xf1\x07\x33\xb4\x1d\x67\x48\x90\x33\xb4\x7c\x52\x19\x06\x38\xb9\x53\x18\x07\x7d\x76\x9d\x71\xd4\x39\x9f\xe7\xe4\xae\xac\xd8\x0d\xbe\x77\xc9\x84\x0e\xfa\x0a\x2c\xc0\x39\x0a\x3d\x5e\x0a\x56\xe6\x3a\x56\xa7\xa4\x98\x10\xb5\x38\xa7\xb0\x5c\xee\xe7\xdb\xad\xc9\xd1\xee\xcc\x22\xe7\x48\x94\xb9\x32\xe8\x72\x0a\xf7\xd7\x0a\x29\x23\x20\x05\x7f\x87\x26\x57\x88\x02\x88\xa0\xf0\xb9\x62\x62\xda\xa3\xb8\xbe\x7c\xb1\x91\xfc\x5b\xa7\x2d\xf4\x79\x84\xe3\x57\x3d\xc6\x49\x14\xfd\xd0\xe5\xd3\xe8\x72\xe0\xb1\x1b\x9a\xf2\xa8\x6d\x18\xc3\x71\xfc\xd6\x6e\xb2\xa6\x39\xb2\x51\x7e\xd0\x11\x03\x12\xb3\x9e\xf0\x92\x59\x63\xf5\x41\x79\xb5\x62\x42\x5b\xd7\xf5\xf0\x35\x63\x78\xd9\x74\x02\x48\x55\x97\x44\x34\x29\x6c\xf7\xf0\xc6\xa8\x7c\x6b\x52\x58\xbb\x7a\x9c\xe6\xdd\x57\x93\x77\x4e\x2f\x6a\x72\xc2\xf0\xa8\x90\xbc\x86\x4c\x34\x8c\x62\x0a\xe4\x2c\x99\x25\xaf\xc1\x91\xaf\x1f\xbe\xe1\xf1\x95\xe9\xb0\xcf\x6a\xb8\xa7\x87\x84\xfe\xd1\x36\x3a\x05\xbc\x90\xdc\x92\xf6\xe2\x27\x72\x8b\xc3\x78\xef\x86\x0e\xbc\x4c\xfa\x86\x73\x89\xc0\xf5\x7b\x7b\x37\xc2\x65\x3f\x36\x60\x3a\x4d\x52\x78\x2b\x99\x76\xe7\xf4\xc2\xd1\xc3\x8d\x8e\x7f\xd6\x58\xcd\x1a\xc8\xbf\x4a\x85\xc5\x7f\x5e\x96\xa5\xd6\xf5\xf2\xdf\x43\xf7\xf7\x78\x7f\x28\x71\xc0\x4c\x83\x01\x9f\x97\x40\xb4\x56\x9f\x8d\xf6\x17\x58\x7e\xb1\x93\x32\xee\x6c\xf6\xe8\xbe\x87\xb3\x47\xfb\x5d\x9d\x99\x0a\xcc\x07\x72\x66\xe9\xc6\xe8\x66\x65\x3c\xfb\x9e\x2e\x63\xa3\xb1\xc8\x04\x39\x1f\xba\x4e\x63\x55\x73\xa2\x11\x96\x5a\xe6\x4b\x58\xfd\xfe\x8f\x6f\xe6\xa3\xdb\x08\x8d\x9f\x47\xe7\xc8\xbc\x50\x76\x86\x9c\x93\xa6\x79\x59\xda\x3c\x97\xc6\xf3\xb7\xe1\xd9\x98\x51\x76\x1e\x54\x07\xfe\x1c\x02\xd5\x87\xae\x63\x05\xac\xfe\x89\xaa\x61\x52\x5c\xaf\x5d\xe7\x3f\xa3\xa0\xc6\xb4\x36\xca\xd9\xe3\x68\x98\x3d\x0e\xc5\x64\x8f\x66\xde\x0e\x9f\xba\x8e\x62\xc1\x84\x4d\xd4\x18\x1a\x9f\xd7\x6b\xd6\xf2\x43\xd7\x29\x22\x4e\xd8\xbf\x72\x76\xc8\x08\x18\xd0\x5e\x96\x0f\x5d\xb7\xfa\xfb\xdf\xae\xd7\xe5\x0c\x00\xf2\x41\xd9\xdf\x4a\xc6\xa9\x42\x61\xe4\x9c\x1d\xc6\xac\x7a\xe7\xfd\x73\xd7\xa1\xa0\xd7\xeb\xff\x07\x00\x62\x28\x8b\xad\xe5\x10\x00\x00")
 
 func templatesBookTmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -84,12 +85,32 @@ func templatesBookTmpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/book.tmpl", size: 5461, mode: os.FileMode(420), modTime: time.Unix(1492122551, 0)}
+	info := bindataFileInfo{name: "templates/book.tmpl", size: 4325, mode: os.FileMode(420), modTime: time.Unix(1792270209, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesWebTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x57\x5f\x6f\xdb\x36\x10\x7f\x76\x3e\xc5\xcd\x45\xd1\x16\x90\x62\x59\xb6\x9b\x56\x76\x8c\x01\x7b\xdf\xd3\xde\x86\x0d\xa0\xc5\x93\xc5\x95\x22\x35\x92\x76\x9c\x79\xf9\xee\x03\x29\x52\xa2\xe4\xf4\xcf\x10\xc0\x91\xc8\xfb\xf3\xbb\xe3\xef\xee\xa8\xdd\x4f\x54\x96\xe6\xb9\x45\xa8\x4d\xc3\xf7\x77\x3b\xfb\x0f\x38\x11\xc7\xc7\x39\x8a\xf9\xfe\x6e\xb6\xab\x91\xd0\xfd\xdd\x6c\xb6\x6b\xd0\x10\x28\x6b\xa2\x34\x9a\xc7\xf9\xc9\x54\xe9\xa7\xb9\xdb\x30\xcc\x70\xdc\x5f\xaf\xac\x82\xfb\x5f\x49\x83\x2f\x2f\xd7\xab\x7f\x80\x7f\xe1\x7a\x45\x41\xdd\xd2\x6f\x56\xee\xe5\x65\xb7\xe8\x14\xac\xaa\x36\xcf\xdd\xd3\xcc\x3a\x4e\xe0\x20\xe9\x73\x02\x94\x9d\x13\xd0\x2d\x11\x09\xc8\xc3\x5f\x58\x9a\x04\xea\x65\x02\x75\x9e\x40\xbd\x4a\xa0\x5e\x27\x50\x6f\x12\xa7\xf5\x31\x81\x36\x81\x03\x97\xe5\x97\xbf\x4f\xd2\x60\x02\xad\xc2\x04\x48\x02\xa5\xa4\x98\x00\x36\x09\xb0\xe6\x98\x80\x36\x4a\x8a\xa3\x53\xa2\x3c\x01\xc9\x13\x38\xf1\x04\x38\xb3\x32\x07\xa4\x09\x54\x52\x1a\x54\x09\xd8\x80\xed\xff\x06\xc5\xc9\xc9\x1b\xd6\x58\x93\x27\xca\x64\x02\x67\x46\x51\x5a\x2f\xf2\xa8\x50\x6b\xb8\x5a\x89\x59\x43\xd4\x91\x89\x02\xb2\xad\x7b\x6d\x09\xa5\x4c\x1c\xfb\xf7\x83\x54\x14\x55\xff\x5a\x49\x61\x0a\x60\xa2\x46\xc5\x4c\xb7\x74\x46\x65\x58\x49\x78\x4a\x38\x3b\x8a\x02\x0e\x44\x23\x67\x02\xdd\xee\xcb\x9d\x53\xba\x85\x07\x57\xa0\x4c\xb7\x9c\x3c\x17\x5d\x0e\xb6\xe0\x64\x3d\xd6\x92\x88\x33\xd1\x03\x58\x8f\xde\x63\xee\x35\x99\xb0\x9e\xd2\xce\xc0\x0f\xa2\x71\x3c\xb9\x82\x8d\x24\xad\x48\xc3\xf8\x73\x01\x35\xf2\x33\x5a\xbd\x6d\xb7\xae\xd9\x3f\x58\x40\x9e\xb7\x17\x0f\xcb\x9e\xae\x77\xee\x3c\xd6\xc8\x8e\xb5\x29\x60\x79\xbf\xee\xfc\x96\x92\x4b\x55\xc0\x9b\xd5\x6a\xe5\x13\x47\xca\x2f\x47\x25\x4f\x82\xa6\x61\xaf\xaa\xaa\x08\xc6\x32\x80\xe8\x9c\x2d\xef\x1f\x36\xd8\x78\x77\x75\x3e\xdd\x8c\xf6\x56\xd3\xbd\x3c\xda\xbc\x25\x1b\xd4\x1f\x3d\xf2\x51\xc8\x9a\x08\x9d\x6a\x54\xac\x4a\xe0\x88\x52\x1d\x19\xd9\x46\x84\x48\x8d\x6c\x0b\xc8\xb3\xf6\x32\x5a\x3d\x48\x63\x64\x53\xc0\x32\x0f\x1b\x1c\x8d\x41\x95\xea\x96\x94\x8e\x37\x61\xc3\x63\xb5\x30\xe0\x0a\xb1\xd1\xe5\xc6\xa6\x75\x6a\x30\xeb\x73\x1d\x30\x8f\x95\xa2\x7d\x17\x63\x48\xc2\x93\x3f\x89\x83\xe4\x14\x55\x9f\xa3\x2e\x7c\x5f\x3a\x70\x78\x4d\xda\xcb\x62\xd3\xe7\xd3\x96\x74\x01\xcc\x10\xce\xca\xed\x58\x21\xcf\xb2\x40\x85\xbe\x62\xbf\x97\xd6\x81\x55\x4e\xce\xe9\xe8\x02\x84\xf4\x74\x1c\x8a\xcd\x46\x77\x93\xec\x02\x32\xc8\xa2\xd5\xae\x14\x53\x8e\x95\x29\x60\xd3\x5e\x40\x4b\xce\x28\xbc\x41\xc4\xa1\x38\x27\x41\x0c\x27\xa1\x3c\xda\xc0\xdc\x6c\xec\xca\xba\xf9\x4a\xcd\x7b\xbf\xdd\x31\xdc\xba\x75\xe6\x5b\x85\xd3\x9a\x6a\xa4\x90\x96\x15\xe8\xf3\x66\xbb\x9a\xc7\xd0\xc7\x9d\xb7\x17\x58\x87\xf8\x22\x4e\x67\xf7\x9f\xb1\x99\x36\xa4\xfb\x6c\x83\xcd\x20\xee\x61\x29\x42\xd9\x49\x17\xd1\x7a\x5f\x78\x05\xbc\xc9\x32\x1f\x84\x3c\xa3\xaa\xb8\x7c\x4a\x2f\x05\x90\x93\x91\xe3\xaa\xad\x3e\xd9\xbf\x71\x38\x11\xde\x49\xa7\x1a\x25\x6e\x19\x25\x2e\xc2\xba\xf1\xf8\x9d\xb9\x93\x6d\x38\x9c\xe9\xfe\x74\x28\xd3\xa5\x4f\x8b\xbc\xd9\xc3\x92\x35\x84\xfb\x6d\xce\x46\x7d\xda\x9f\xfe\x7a\xf3\x95\xba\xec\xd7\x27\x5d\x2a\xdf\xc4\x68\xba\x21\x62\x87\x49\x3b\x94\xd9\x2b\x95\x48\x12\x20\xc5\x99\x69\x66\x90\xc2\x15\x86\x1e\xf7\x40\x0e\x0f\x41\xa6\xa8\x6d\x6e\xad\x24\x29\x0d\x3b\x87\x9c\xdd\xf6\x3f\xa3\x88\xd0\x2d\x51\x28\xfc\xe4\x30\x78\x31\x29\xc5\x52\x2a\x62\x98\x14\x05\x9c\x04\x45\xd5\x77\xeb\x99\x3c\x19\xfb\xd2\x13\xd1\xdb\x89\xa7\xcf\x14\x42\x25\xcb\x53\x98\x6c\xdf\x33\x1f\xc2\xc9\x57\x9b\xfc\xa1\x8c\xcd\xc5\x56\x7a\x10\xa6\x66\x02\xa8\x34\x06\xe9\xff\x82\x9f\xca\xaa\xd2\x68\x0a\x48\xc7\xad\x71\x69\x47\xbd\xfd\x0d\x19\x76\x5d\x8d\x74\xbf\xd1\xda\xca\xad\xad\xa2\x35\xa7\xbe\x76\xcb\xeb\x58\xb4\xeb\x76\x76\x3d\x3c\x0d\x87\x37\x8a\x78\xbd\x5e\xff\x50\x08\x0e\x28\x6b\x8e\xdf\x28\x83\xd0\x2b\x86\xa6\xd6\x90\x4b\xfa\xc4\xa8\xa9\x2d\x95\xb2\xb7\xdb\x51\xd7\x19\x4a\x6f\x3a\xa8\x1b\x46\x29\x8f\xc7\xb4\xbf\x2c\xdc\x97\x52\x18\x14\x26\xdc\x72\xfa\x72\xe8\xbd\x7c\xfe\x74\xd3\x38\x5d\x37\x1b\x7c\x0d\x75\x09\xf9\xdb\xa9\x8b\x3d\x89\xa8\x6d\xfb\x05\xdc\x64\xc5\x05\x07\x91\x0e\xb8\xe9\x3d\x2d\x9c\x4d\x5f\x38\x01\x6a\x67\xca\x47\x58\xa2\x30\xfd\x84\xba\xb7\xb5\xd1\x16\xa4\x1a\x42\xf2\x81\x16\x30\x9f\x6f\xc7\xf9\x36\xe4\xc0\x03\x69\x39\x12\x65\xe7\x97\xa9\x87\x40\x7e\x6e\x90\x32\x02\x52\xf0\x67\xd0\xa5\x42\x14\x40\x04\x85\xf7\x0d\x13\x43\x92\x96\xed\xe5\x43\x98\x01\xd1\x15\xc8\x5f\x22\x3e\xf7\xd8\x7f\xd4\xe2\x32\xcf\xb2\x6f\x9a\xfc\xd8\x9b\xec\x1a\xc9\xa4\x4f\x24\x23\x96\x2f\x03\xf3\x7b\xc6\xaf\x63\x22\x7b\x2f\xb7\x84\x1d\x78\xf7\xe2\x89\x36\x9c\x4f\x04\x66\xd5\xdf\x90\xe2\xf0\x5a\xc5\x84\x09\x33\xa9\xbb\x55\x4b\x77\xb3\x86\x2b\x48\xd5\xd6\x44\xe8\x02\x36\x5b\x78\x62\x54\x3e\xe9\x02\x56\x21\x9e\x20\x79\x73\x7b\x8f\x86\xc6\xac\x25\x47\x4c\x0f\x0a\xc9\x97\x94\x09\xcd\x28\x16\x40\xce\x92\xd1\x11\xdc\xfe\xae\x06\x57\x88\x14\x1c\x2f\x82\xbc\x77\xfa\x4a\x8a\xfb\xa8\xfc\x05\x15\xe2\xe1\xf7\x54\x33\x13\x68\x3b\x0b\x65\x34\x91\x89\x7b\xb2\x97\x6c\x81\xfc\x5e\x2b\xac\xfe\x7c\x9c\xd7\xc6\xb4\xf3\x3f\x3a\x92\xba\xb4\xbc\xba\x13\xe2\x1d\xf8\x0b\xef\xe7\x40\x8c\x51\xef\xad\xf4\x07\x98\x7f\x98\x47\x31\xdb\x9f\xdd\x22\x7c\x3e\xed\x16\xfe\x2b\x6d\x67\x23\x70\x5f\x56\xbe\x2c\xad\xec\xae\xce\xf7\x3b\x02\xd6\xcc\xe3\xbb\xc5\xbb\x7d\xfc\x29\x46\xf6\xbb\x45\x9d\x3b\x8d\x45\x50\xb1\x2f\x94\x9d\xa1\xe4\x44\xeb\xc7\xb9\x47\x34\xb7\x7a\xbf\x74\xcf\x56\x93\xb2\xb3\xf3\xdc\x79\xdc\x2d\xdc\x07\xe4\x7f\x01\x00\x00\xff\xff\x50\x16\xaa\x1c\x50\x0e\x00\x00")
+var _templatesSlidesTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x57\x59\x6f\xeb\xb8\x15\x7e\x56\x7e\xc5\x19\x5d\x14\x50\x1a\x49\x5e\x12\x67\x91\xe5\x74\x6e\x3b\x03\x4c\x81\x69\x50\xf4\xb6\x4f\x45\x1f\x68\xf1\xc8\x62\x4d\x91\x1a\x92\xde\x46\xd7\xff\xbd\xa0\x44\xc9\xf2\x92\xc2\x0f\x26\xcf\xbe\x7c\x3c\xa4\xd2\x1f\xa8\xcc\xcc\xa1\x42\x28\x4c\xc9\xdf\xef\x52\xfb\x07\x9c\x88\xd5\xc2\x47\xe1\xbf\xdf\x79\x69\x81\x84\xbe\xdf\x79\x5e\x5a\xa2\x21\x90\x15\x44\x69\x34\x0b\x7f\x63\xf2\xe8\xd5\x3f\x31\x04\x29\x71\xe1\x6f\x19\xee\x2a\xa9\x8c\x0f\x99\x14\x06\x85\x59\xf8\x3b\x46\x4d\xb1\xa0\xb8\x65\x19\x46\xcd\x26\x04\x26\x98\x61\x84\x47\x3a\x23\x1c\x17\x93\xd6\x8c\x61\x86\xe3\x7b\x5d\xc7\xff\xb4\x8b\xe3\x31\x1d\xb5\x14\xcb\xd3\xe6\xd0\xae\xbc\x3f\x42\x0d\x25\x51\x2b\x26\x12\x18\xcf\xa1\x22\x94\x32\xb1\x6a\xd6\x4b\xb9\x8f\x34\xfb\xbd\xd9\x2e\xa5\xa2\xa8\xa2\xa5\xdc\xcf\xe1\x68\xf5\x6c\x62\x21\x2c\x25\x3d\x40\x6d\xf7\x5e\x81\x6c\x55\x98\x04\x26\xe3\xf1\x1f\xe6\x0d\x45\x6e\x51\xe5\x5c\xee\x12\x28\x18\xa5\x28\x5a\xea\x92\x64\xeb\x95\x92\x1b\x41\x13\xf8\x32\x9d\x4e\x5b\x6a\x2e\x85\x89\x72\x52\x32\x7e\x48\xe0\x17\xe4\x5b\x34\x2c\x23\x21\x7c\x55\x8c\xf0\x10\x34\x11\x3a\xd2\xa8\x58\xde\x88\x37\x11\xc4\x9a\x33\x8a\xce\x3b\x65\xba\xe2\xe4\x90\x80\x90\x02\x1b\x19\xaf\x92\x9a\x19\x26\x45\x02\x64\xa9\x25\xdf\x18\x47\x37\xb2\xb2\xe9\x35\x6b\x8e\xb9\xe9\x37\x4d\x31\x87\x09\x5c\xa7\xd4\x97\x67\xb6\x2d\xe0\x75\xbb\xbb\x91\x52\x9e\xb7\x31\x7a\x99\xe4\x52\x5d\xe5\xa8\xd9\xef\x98\xc0\x63\x3c\xdb\x16\x2e\x04\x26\x30\xea\x3d\xc5\x4f\x97\xb5\x23\x1b\x23\x2f\x93\x8e\xb3\x8d\x52\x28\x0c\xd4\xd0\x27\xbe\xe4\x32\x5b\x77\xcd\x99\x84\x50\x4c\x43\x28\x1e\x43\x28\x9e\x42\x28\x66\x21\x14\xcf\x7d\xa7\xa3\xa5\x34\x46\x96\x09\x8c\xe3\x19\x96\x73\xb8\x08\x61\xda\x5b\x81\x1a\x06\x41\x4f\xe3\x27\x2b\xdd\xf2\xa6\xe7\xbc\x49\xfc\x7a\xe2\x3d\x5e\xf2\x4e\x7a\x55\x08\x1b\x1e\x82\xe4\x21\x54\x0a\xc3\x36\xea\xdf\x36\xd2\x60\x08\x86\x2c\x39\xde\x0a\xf2\x65\xd6\xeb\x73\x76\x12\x68\x9b\x37\x89\x4f\x5c\x02\x35\xf4\x65\x27\x2f\x04\xbb\x54\x58\xb9\x6a\xf4\xf6\xd1\xb0\xc9\x0d\xa1\xcb\xfb\x65\xbc\x2d\x9c\xf4\x29\x28\xa8\xbb\x13\xd1\x79\xb3\xbe\xdc\x61\x68\x29\x4f\xd5\x1e\xb4\xe4\x8c\xc2\x17\x4a\xe9\xbc\xf7\x3f\x9b\xcd\x9c\xb9\x4c\xf6\x30\xed\xf1\x33\x8e\xc7\x33\x2c\xe1\xa9\xda\x5f\x61\x63\x1c\xbf\x61\x79\xe3\x54\x94\x52\x48\x5d\x91\xcc\xc1\xd8\x05\xa1\x08\x65\x1b\x9d\x9c\x2c\x9d\x81\x71\x3c\x76\xd8\xee\xa2\xca\x5f\xed\xef\x84\xa7\x4a\x21\x64\xf2\xfa\x18\x35\x25\xb8\xc0\x7c\x8b\x96\x33\x78\x46\xfb\x2b\x80\x16\x3c\x5a\x87\x10\x17\x3c\xa2\x83\x6e\xe4\x6f\xd3\xe7\x97\xae\x1b\x96\x69\x5a\x99\x72\x20\xf3\xfc\x4c\xdf\x30\x1f\xc8\xe8\x01\x13\x9f\xe9\xf2\xe5\x69\xc0\x14\x03\x26\xc1\xd7\x49\x3e\xd4\x24\xad\x75\x36\xb0\x4e\x9e\x71\x3a\xc5\x81\xcc\x76\xc0\xcc\xe9\xdb\xcb\x64\x68\x20\x1b\x30\x5f\x66\x2f\x93\x19\xce\x1d\xa4\xed\xe4\x4c\x80\x19\xc2\x59\xd6\x29\x08\x69\x50\x0f\x0f\x63\x33\x85\x1c\x53\x57\x48\xd6\xa8\xa0\x93\xfa\xbc\xd0\xfd\xc0\xca\xd9\x1e\x69\xd7\x66\x77\x0a\x6e\x0c\x2c\xd5\x22\xd7\xed\x86\x60\x7e\xbc\x1a\x58\x13\x2c\x4f\x03\xeb\xc6\x74\x39\x1f\x62\x8f\x8f\x8f\xe7\xb8\x41\xc4\x1b\x40\x7d\x76\x78\x68\x13\xad\x94\x5c\x29\xd4\x5d\x8a\x37\xd3\x71\x21\x4f\xb0\xbc\x48\xef\x84\xad\xce\xe5\xdb\xdb\xdb\x95\xcb\xa9\x9b\x9a\x8d\xc3\x1f\x4b\xa4\x8c\x40\xa5\x98\x9d\x84\x96\xe2\xfd\x58\x91\x95\x1d\x21\x6d\x80\x9c\x08\xaa\x33\x52\xe1\xdc\x8d\x0c\x5b\xab\xb6\x2b\x67\xf7\x17\x74\x65\x6b\xaa\x01\xa7\xea\x6c\x99\x66\x4b\x8e\x73\x18\x16\x67\x57\x30\xd3\x35\xd7\xcd\xe3\x10\x2e\xe7\xf2\x9d\xf7\x59\x97\x07\x75\x51\xc8\x89\x61\x5b\x57\xd9\xeb\xfb\x67\x78\x01\x75\xd7\xc5\x27\xb7\xaa\x67\x13\x8f\x96\x0a\xc9\x3a\x22\xb9\x41\x95\x00\xe1\x3b\x72\xd0\x8e\x7d\xc6\xb1\xb2\x2d\x7d\x98\x44\xc2\x89\x36\x91\xcc\xa3\xe6\xfd\x52\xc3\x0d\x8b\x4d\x79\x6e\x90\x9c\x99\x06\xe0\x21\x5c\x20\x3e\x84\x01\x32\x6e\x1e\x11\x6f\x30\x81\xbc\x68\x87\xcb\x35\x33\x51\xd3\xd6\xa8\x01\x43\x44\xe8\x7f\x37\xda\x24\x80\x7b\x92\x99\x2e\xe1\xff\xcf\x3f\x76\x30\x49\x47\xdd\x63\x27\x1d\xb9\xb7\x57\x6a\x9f\x2d\xf6\xf5\x53\xd7\x8a\x88\x15\x42\xfc\xcd\x16\x40\x1f\x8f\xa9\xc6\xcc\x62\x16\x32\x4e\xb4\x5e\xf8\x4d\x57\x7d\x60\xd4\x2d\xa3\xba\x8e\x3f\x36\xe5\x12\xd5\xf1\xd8\xbc\xb2\xbc\xba\x8e\xff\xd2\x3e\xcd\x8e\x8d\xcb\xba\x66\x39\xc4\x1f\xb6\x12\xc7\x63\x4a\xb4\x7d\xa2\x38\x63\x4d\x35\x7c\xfb\x22\xeb\xd8\xa3\x86\xff\x5e\xd7\x28\xe8\xd1\x05\xdb\x06\x60\x6d\x3b\x72\x4a\xd9\xb6\x33\xd1\x15\xd2\x7f\x4f\x47\x94\x6d\xad\x54\xaa\x33\xc5\x2a\x63\x97\x5e\x90\x6f\x44\xa3\x1e\xdc\xbb\x72\x6e\x89\x82\x26\x72\x0d\x0b\xa0\x32\xdb\x94\x28\x4c\xfc\xdb\x06\xd5\xe1\x1b\x72\xcc\x8c\x54\x5f\x39\x0f\xfc\x16\x02\xfe\xfd\xbc\xd7\xea\x7b\xf6\x99\x5e\xe0\xf7\x7d\x1d\xea\x75\x47\x60\xd1\xcd\xa5\x2e\x26\xd0\x85\xdc\x05\xa2\x8b\xcc\x63\x39\x04\x3f\x34\x6e\x75\xcc\x51\xac\x4c\x71\x0f\x0a\xcd\x46\x75\x90\x3e\x59\xfa\x1b\x31\x45\x5c\x92\x7d\x30\x0e\xdd\x9a\x89\xe0\x4c\x15\x22\x98\x84\x20\xee\x5d\x20\x5e\x2e\x15\x04\x36\x1c\xd6\x04\x02\x0c\x52\x38\x53\x98\x03\x7b\x78\xe8\x63\xf1\x5a\xde\xbf\xd9\x7f\xe2\xa6\xd2\x1f\xa4\x44\x58\x00\x83\xc5\x62\xd1\x67\xf4\x27\x68\x41\xd0\x11\x7c\x48\x1c\xc5\x77\x5e\x8f\x1d\x34\xdb\xd2\xc5\x06\xf7\xc6\xa1\x03\x16\x10\x74\x86\x1e\x60\x72\x0f\x0f\xe0\xc3\x08\x7c\x78\xb8\x88\xcb\x9d\x7c\xa6\x8d\x54\x87\x58\x61\xc5\x49\x86\xdf\x0c\x31\x18\x88\x0d\xe7\x21\xf8\x7e\x08\xfe\x17\xab\x78\x66\xd0\x65\xde\x86\xd0\x77\x8c\x50\xfa\xf3\x16\x85\xf9\x95\x69\x83\x02\x55\xe0\xaf\xf1\x40\xe5\x4e\xf8\x21\xf4\x60\xc1\xbe\x0e\x7a\xc7\x4c\x56\x40\x80\xf1\x1a\x0f\x3d\x35\x23\x1a\xc1\xff\xaa\x94\xdc\xfd\xc3\x0e\x4a\x3f\x81\x01\xe9\x27\x6b\xad\xa3\xfc\x9d\xac\xf0\x8c\x00\x7e\xd2\x36\x7e\x18\xab\x1b\x22\xf3\x2b\xf3\xbf\x62\x7e\x61\xfd\x5f\x55\xbf\xb7\xb6\x07\xdb\x3f\x93\x6c\xdd\xbc\x87\x2e\x3d\x44\x9f\x78\xf8\x45\x96\xbd\xec\xf8\xa6\xc4\xcf\x82\x76\x02\x67\x4d\xf9\xd4\xe4\x29\xcf\x0f\x3f\x39\x9d\x13\x3b\x5f\x5a\x20\xd9\xba\xc7\x46\xae\x56\x1c\x03\xdf\xcd\x45\xff\xc2\x14\xc5\x9c\x6c\xb8\x49\xce\xd1\xef\xb0\x84\x71\xa5\xd0\x76\xf0\xa7\x56\x2a\xe8\xfa\xec\xfe\x9b\xda\x06\x95\xfd\x8a\xfc\xab\x30\x01\x97\x19\xb1\x3d\x8d\x0b\xa2\x0b\x7b\xa8\x33\x0c\x26\xf7\x21\x4c\xc6\xf7\xf0\xfd\xbb\xc5\x5d\x93\x8a\x55\x3d\xde\xb7\xb6\xd2\xd1\x69\x82\xa4\x42\xba\x8d\xfb\x44\xec\x3e\xb2\xae\xbe\x33\x6e\xdc\x62\x50\x32\x71\xfa\x8c\xb0\xb7\x18\x1c\xbb\xe9\x9b\x8e\x7a\xcb\x76\x10\xdb\x02\xbd\xdf\xa5\xa3\xc2\x94\xfc\xfd\xee\x7f\x03\x00\x8f\x1f\x65\x2b\x36\x0f\x00\x00")
+
+func templatesSlidesTmplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesSlidesTmpl,
+		"templates/slides.tmpl",
+	)
+}
+
+func templatesSlidesTmpl() (*asset, error) {
+	bytes, err := templatesSlidesTmplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/slides.tmpl", size: 3894, mode: os.FileMode(420), modTime: time.Unix(1792270817, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesWebTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x58\xdd\x8f\xe3\xb6\x11\x7f\xd6\xfe\x15\x53\x1f\x82\xd8\xa8\x2c\x7f\xad\x77\xef\xb4\xb6\xdb\x20\x0d\xd0\x87\x6b\x52\x34\xe9\xd3\xe5\x02\xd0\xd2\xc8\x62\x96\x22\x75\x24\xe5\xf5\xc6\xf1\xff\x5e\x90\x22\x25\xfa\xe3\x2e\x29\xfc\x20\x8b\x33\x9c\xef\xf9\x0d\xc5\xd5\x5f\x72\x91\xe9\xd7\x1a\xa1\xd4\x15\xdb\xdc\xad\xcc\x03\x18\xe1\xbb\xf5\x00\xf9\x60\x73\x17\xad\x4a\x24\xf9\xe6\x2e\x8a\x56\x15\x6a\x02\x59\x49\xa4\x42\xbd\x1e\x34\xba\x18\xbf\x35\x0c\xd1\x4a\x53\xcd\x70\x73\x3c\xd2\x02\x92\x7f\x93\x1d\x26\x3f\x99\x85\xd3\xe9\x78\x3c\x7b\x85\xdf\xe1\x78\x44\xa6\x10\x0c\xe3\xf7\xa4\x6a\x59\xda\x3f\x2d\x91\xe7\x76\xc9\x6d\x58\x4d\x5a\xc9\x77\x51\x14\x08\xff\x07\xaa\x4c\xd2\x5a\x53\xc1\x4f\xa7\xd6\x28\x4e\x2a\x5c\x0f\xf2\x9e\x30\x80\x4c\x70\x8d\x5c\xaf\x07\xc7\xe3\x8d\x6d\x83\x8d\x53\x76\x2e\xfa\x9b\x46\x97\x42\x9e\x4b\x25\x76\xed\x86\x40\xcf\x1c\xca\x5a\x29\xfd\xda\x1a\x1c\x99\x40\xc6\xb0\x15\xf9\x6b\x0c\x39\xdd\xc7\xa0\x6a\xc2\x63\x10\xdb\x5f\x31\xd3\x31\x94\xb3\x18\xca\x79\x0c\xe5\x22\x86\xf2\x3e\x86\x72\x19\xdb\x5d\x0f\x31\xd4\x31\x6c\x99\xc8\x9e\x3f\x35\x42\x63\x0c\xb5\xc4\x18\x48\x0c\x99\xc8\x31\x06\xac\x62\xa0\xd5\x2e\x06\xa5\xa5\xe0\x3b\xbb\x29\x67\x31\x08\x16\x43\xc3\x62\x60\xd4\xf0\x6c\x31\x8f\xa1\x10\x42\xa3\x8c\xc1\x24\xd0\x3c\x2b\xe4\x8d\xe5\xd7\xb4\x32\x22\x9b\x9c\x8a\x18\xf6\x34\x47\x61\xb4\x88\x9d\x44\xa5\xe0\x68\x38\xa2\x8a\xc8\x1d\xe5\x29\x4c\x9f\xec\x6b\x4d\xf2\x9c\xf2\x5d\xf7\xbe\x15\x32\x47\xd9\xbd\x16\x82\xeb\x14\x28\x2f\x51\x52\xdd\xee\xd8\xa3\xd4\x34\x23\x6c\x4c\x18\xdd\xf1\x14\xb6\x44\x21\xa3\x1c\x2d\xd5\x84\x2a\xba\x65\x1e\x1c\x21\xa7\xaa\x66\xe4\x35\x6d\x63\xf0\x04\x96\xd7\xd9\x9a\x11\xbe\x27\xaa\x37\xd6\x59\xef\x6c\xee\x76\x52\x6e\x34\x8d\x5b\x01\x7f\xd2\x1a\x93\x2e\x38\x82\xf1\x64\x5c\x90\x8a\xb2\xd7\x14\x4a\x64\x7b\x34\xfb\x9e\xda\x75\x45\x7f\xc3\x14\xe6\xf3\xfa\xe0\xcc\x32\xd9\x75\xca\xad\xc6\x12\xe9\xae\xd4\x29\xcc\x92\x7b\xeb\x67\x94\x09\x26\x64\x0a\x6f\x16\x8b\x85\x0b\x1c\xc9\x9e\x77\x52\x34\x3c\x1f\x7b\x5a\x51\x14\x81\x19\x33\x6f\x44\xab\x6c\x96\x3c\x2e\xb1\x72\xea\xca\xf9\x25\x31\xa0\x2d\x2e\x69\xf3\x80\x78\x5d\x6c\x50\x3e\x38\xcb\xcf\x5c\x56\x84\xab\xb1\x42\x49\x8b\x18\x76\x28\xe4\x8e\x92\xa7\xa0\x20\xc6\x5a\xd4\x29\xcc\xa7\xf5\xe1\x6c\x75\x2b\xb4\x16\x55\x0a\xb3\xb9\x27\x30\xd4\x1a\xe5\x58\xd5\x24\xb3\x75\xe3\x09\xce\x56\x53\xf3\x70\x84\x50\xe8\x6c\x69\xc2\x7a\x29\x70\xda\xc5\xda\xdb\x7c\xbe\x29\xa0\x5b\x1f\x7d\x10\x5e\x5c\x26\xb6\x82\xe5\x28\x3d\x8b\x73\xdf\xb5\x0e\x6c\x6f\x71\x3b\x5e\xac\x3c\xd1\xb6\x74\x0a\x54\x13\x46\xb3\xa7\xf3\x0d\xf3\xe9\xd4\xf1\xf7\x1d\xfb\x47\x61\xed\xab\xca\xf2\xd9\x2e\x57\x29\x70\xe1\xca\xb1\x6f\xb6\xd9\xb4\x3e\x5c\x05\x3b\x85\x29\x4c\x83\xd5\xb6\x15\xc7\x0c\x0b\x9d\xc2\xb2\x3e\x80\x12\x8c\xe6\xf0\x06\xd1\x89\xbb\xe1\x44\x9f\x09\xe9\xac\xf5\x95\x3b\x3d\x57\x65\xd4\x7c\xa6\xe7\x9d\xde\x36\x0d\xd7\x6a\xad\xf8\x5a\xa2\x8f\xa2\x8f\x44\x25\xb8\x30\x55\x81\x2e\x6e\x06\xd5\xe0\x78\xee\xf7\xbc\x3e\xc0\xbd\xf7\x2f\xa8\xe9\x69\xf2\x0e\xab\x4b\x40\x4a\xa6\x4b\xac\xe0\xfe\x22\x1c\x92\xe4\xb4\x51\x69\xb0\xde\x35\x5e\x0a\x6f\xa6\x53\xe7\x84\xd8\xa3\x2c\x98\x78\x19\x1f\x52\x20\x8d\x16\xe7\x5d\x5b\xbc\x35\xbf\x73\x77\x02\x7b\x2f\x90\xea\x2c\x70\xb3\x20\x70\x81\xad\x4b\x67\xbf\x75\x3d\x29\xd9\xf8\x39\x06\xf3\xc8\xe1\x08\x9d\xd6\x77\xf3\x87\xc7\xb9\x0b\x8f\x21\xea\x96\xa7\x0a\x78\x1e\x1e\xf2\x77\x58\x04\x3c\x2a\x20\xe2\x43\xbe\x7d\xbc\x0f\x88\x3c\x20\x12\x7c\x3b\x2b\xc2\x9d\xa4\x95\x4e\x43\x9e\x07\x9c\xcf\x7d\x82\x0c\x71\x1f\x10\x8b\xfc\xdd\xe3\x2c\x14\x90\x05\xc4\xc7\xe5\xe3\x6c\x89\x4f\x70\xa3\xe6\xda\x0d\x0d\x83\x23\x30\xaa\x3a\x6a\x4e\x95\xa7\x89\x2b\x1a\x66\xb4\x22\xcc\x91\x19\x75\x71\x77\x00\xd0\x56\xfc\xfd\xd2\x67\xf8\x02\x3a\xba\xf5\x0b\x64\x9e\x2f\xfb\x0c\x34\x6e\x70\x9a\x01\x5a\xc3\xf1\x0b\xe8\x43\x62\x20\xe9\x9e\x2a\xaa\x31\xcc\xd5\x62\xf1\x48\xb6\x8f\x9e\x27\x2d\x4d\x3d\x19\x4e\x92\x69\xba\xf7\x75\x72\x8d\xf9\x5a\x12\xae\x6a\x22\x91\xbb\x69\xa9\xf1\xa0\xc7\x39\x66\x42\x12\x73\x7c\x49\xa1\xe1\x39\xca\x6e\x42\x45\xa2\xd1\xe6\xa5\x6b\x3e\x27\x27\x9c\xb8\x97\x26\x14\x22\x6b\xfc\x34\xff\x23\xf1\x4e\xdc\x9b\xf9\x62\x39\x7f\x0c\xe0\xe1\x5c\x4a\x67\x84\x2e\x29\x87\x5c\x68\x8d\xf9\xff\x65\xfe\x58\x14\x85\x42\x9d\xc2\xf8\x7c\x1c\xcc\xcc\xf1\xa6\x9c\xf5\x11\xb6\x48\x6e\xd6\xe6\x67\x6b\x0b\xcb\xb7\x08\xd6\x8c\x8c\xf2\xde\x2e\xdf\x87\xac\x2d\xc2\x03\xe9\xff\x79\x1a\x1c\xcf\x3c\xbe\xbf\xbf\xff\x53\x2e\x58\x43\x69\xb5\xfb\x42\xeb\x7b\x7c\xec\x81\xbc\x22\x87\xf1\x0b\xcd\x75\x69\x4a\x69\xfa\xd5\xd3\x19\xd2\xf6\x70\x73\x79\x38\xa9\x68\x9e\xb3\x40\xad\x3f\x20\x25\xee\x08\xea\x4f\x76\x70\xbc\xd4\xf2\xee\xed\xd5\xb0\x98\x1b\x20\xea\x75\xf5\x58\x04\xf3\xaf\x2e\x55\x6c\x48\x50\xda\x06\x23\xe1\x2a\x2a\xd6\x39\xd7\xfb\x0a\x89\xcc\x4a\x38\x42\x2d\x14\x6d\x83\x26\x91\x11\x53\xf9\xae\x23\x3c\x0b\xe5\x75\xa3\x9d\xb9\x57\x01\xe9\x4c\xba\xaf\x0f\xf0\xf6\xcb\xb0\xef\x63\x1c\x0c\x9c\x2c\xcb\x42\xda\x0d\xe4\x17\x07\x23\xc8\xaa\x70\x3c\x5b\x11\x94\x9f\x37\xd2\x40\x93\x59\x8c\x7a\x77\xc8\x56\x09\xd6\x68\x97\xce\x16\x70\x5c\x07\xca\xb3\x79\xf9\xdb\x98\xf2\x1c\x0f\x29\xcc\x9e\x2e\x5a\x3e\x38\xe0\xdd\xb2\xfe\x6c\x5c\xf6\x96\xa4\x58\xd5\xfa\x35\x3c\x0d\xdf\x08\x3c\xa3\x1d\x64\xb5\x93\xbf\x3d\x42\x85\x08\x7a\x63\x97\xaa\x08\x63\x37\xce\xd9\x3e\xef\x8f\x8f\x1e\xcf\xda\xba\x83\x72\x76\x03\x19\x97\x1d\x32\xfa\x5a\x6c\x6b\xc5\x95\x70\x86\x5c\x77\xc7\xae\xc4\x44\xa2\x4e\x49\xd1\xd7\xac\xab\xe4\x14\x06\x83\xa7\xf3\x86\xd2\x64\xeb\x6a\x3f\xca\x18\x12\x69\x0e\x65\xba\xec\x63\xf4\xf7\x0a\x73\x4a\x40\x70\xf6\x0a\x2a\x93\x88\x1c\x08\xcf\x61\x58\x51\xde\x77\xc1\xac\x3e\x8c\x9c\xa6\xf0\x5c\xef\x4e\xc6\xef\x3a\xdb\xff\xac\xc4\xd9\x7c\x3a\xfd\xa2\xc8\x87\x4e\x64\x3b\x29\x2e\x06\x41\x30\x3c\x5a\xa0\xeb\x00\x6e\xe1\xb1\xab\x47\x2a\xa7\xe5\x1a\x91\x7a\x60\x39\xdd\x45\x17\xf9\x09\x8c\x59\x60\x75\xc3\xbd\x5a\x52\xee\x5b\xb0\x6e\x3f\x15\xcd\xe4\xb3\x35\x24\x64\x5d\x12\xae\x52\x58\x3e\xc1\x0b\xcd\xc5\x8b\x4a\x61\xe1\xfd\xf1\x9c\x57\x9f\xa4\xc1\x49\x28\xaa\xc9\x0e\xc7\x5b\x89\xe4\x79\x4c\xb9\xa2\x39\xa6\x40\xf6\x82\xba\xf1\xd0\x0a\x0a\xf9\xc7\x2f\xb8\x7d\xa6\x7a\x6c\xad\x6a\xbf\x84\xc6\x24\xff\xb5\x51\x3a\x05\x3c\x90\xcc\x8d\xc5\xe8\x0f\xe8\x2e\x0e\xdd\x97\x8d\xc1\xa2\xde\x12\x5b\x70\xde\x10\x38\x7d\x2e\x77\x5d\xb8\xdc\xe7\x1c\x84\xcd\xfb\x52\x52\xed\x3b\x28\xf2\x00\x7c\xc1\x13\x4e\x73\xc7\x59\x03\xf9\x50\x4a\x2c\x7e\x59\x0f\x4a\xad\xeb\xc1\xc7\xb6\xfa\x6d\xbc\x6f\x52\x7c\x60\xfa\xc6\x80\xe1\x00\x88\xd6\x72\x68\xb8\x47\x30\x18\xb9\x4e\xe9\x32\xbb\x9a\xf8\xcb\x86\xd5\xc4\xdd\xd1\xac\x8c\x07\xe6\xf6\x61\xe5\x00\xdd\xf0\xae\xca\xf9\x66\x45\xc0\x88\x59\x7f\x3d\xf9\x7a\x13\xde\xaf\x90\xcd\x6a\x52\xce\xcd\x0e\x77\x0d\xf2\xa3\x45\xa0\xd3\x69\x55\x08\x59\x41\xc6\x88\x52\xeb\x41\x8b\x1c\x03\xc8\x89\x26\x2d\xd0\xd9\x5b\x10\xcf\x3b\x00\xc1\x55\xb3\xad\xa8\x5e\x0f\x24\xea\x46\x72\x28\x08\x53\x68\x2f\x87\xa2\x68\xd5\xa2\xbf\xb9\x64\xea\x45\xd5\x8c\x64\x58\xda\x8f\xb3\xf5\xe0\x47\xb7\x48\x24\x25\x63\x46\xb6\xc8\xba\x35\x27\xa2\x61\x9b\xd5\xa4\x61\xf6\x6d\x35\x31\xb6\x85\x97\x2e\x13\xef\xad\x79\xc9\xe9\xde\xdb\xed\x82\x69\x2e\x68\x92\x6f\xdb\xff\xc6\xe9\x9c\xee\x37\x77\x57\x0e\xb7\xb7\x43\x86\x10\x0d\x8b\x86\x67\xa6\xe7\x86\xbe\xe5\xf7\x44\x82\xd1\x0a\x6b\xc8\x45\xd6\x54\xc8\x75\xf2\xa9\x41\xf9\xfa\x23\x32\xcc\xb4\x90\xc3\x81\xc3\xd7\xc1\x28\x06\x1b\x22\x37\xdb\x89\x84\xd6\xff\xb5\x15\x70\xb9\xcb\xd2\xcc\x1e\x89\xaa\x61\x5a\x7d\x86\xad\x61\x83\x51\x2f\xd0\xdc\xa5\xc0\x1a\x38\xbe\xc0\x7f\xff\xf3\x7e\x68\xe5\xee\x50\x7f\xa3\xb5\xa4\xdb\x46\xe3\x70\xd0\x67\xca\xc8\x66\x22\xb3\xe3\x3b\x31\x35\xe0\xe4\x78\x17\x41\xa3\xac\xd4\x50\x79\x4f\x23\x97\x41\x95\x68\xf1\x5e\xbc\xa0\xfc\x96\x28\x1c\x8e\x12\x55\x33\xaa\x87\x93\x0f\xbf\xfc\x5c\x1f\xdf\x9f\x7e\xae\x8f\xdf\x9f\x3e\xfe\x75\xd2\x8c\x92\x82\x32\x8d\xb2\x0f\x99\x1e\xc1\x11\x9c\x10\x9d\x30\xe4\x3b\x5d\xc2\x06\x66\x4f\x70\x72\xaa\x4f\xe7\x06\xb4\x71\xeb\x42\x1d\xb9\x48\x24\x94\x73\x94\xff\xfc\xe9\x5f\xef\x61\xdd\x8d\x09\xeb\xfe\x27\x58\x3b\xab\x6d\xf4\x92\x3d\x61\x0d\x8e\xcc\xa9\xa8\xe1\xb9\x89\x4b\xc3\x98\x63\xff\x94\x14\x42\x7e\x47\xb2\xf2\xdc\xbe\x96\x68\x85\x19\xe4\x32\x51\x3f\x9e\xdc\x96\xe8\x07\x7b\x43\x97\x3c\xe3\xab\x1a\xda\x4c\x26\x56\xd9\xe8\x5a\xd4\x73\x2f\x2a\xa2\x05\x0c\x9f\x13\xcb\xff\x43\x31\xd4\x23\x58\xaf\xd7\x30\x0d\x18\xa2\x40\xd6\x87\xe7\x8f\xd7\xd2\xe8\xc8\xc1\x97\xfa\x40\x3f\x1a\x17\x65\x83\x7d\xd0\xba\xb8\x45\x51\xbf\xe4\x3d\x76\xcf\x75\xeb\x3b\xfc\xcd\xa2\xa0\x82\x14\x42\x5f\x2c\xd3\x28\x91\x98\x37\x19\xf6\x6a\x8b\x18\xe8\x85\x1f\xde\x08\x63\x50\x71\x66\x8c\xe7\x72\xf9\x75\xe7\x9a\x28\x3a\xc5\x70\xec\xcc\xea\xfe\x5c\x69\x87\xdf\x7f\x37\x7c\x89\x62\x34\xc3\xe1\x34\x86\xd9\xf4\x46\x58\x03\x73\x6c\x86\x60\xdd\x76\x54\xe2\xed\xb2\x30\x1a\x74\x62\x26\x91\x68\xfc\x8e\xa1\x79\x1b\x0e\x18\x35\x45\x4f\xbe\xc0\x41\x7c\x37\x45\x11\xb1\x4d\x11\x74\x53\x9d\x34\x92\xc5\xf6\x86\x70\x64\x69\x3d\xa7\x99\xc8\x0e\x47\x60\x0d\x75\x62\x6f\xa7\x8d\x4f\x76\x8f\xe7\x63\x34\x21\x75\x8d\x3c\xff\xb6\xa4\x2c\x1f\x92\x4e\x95\x0d\x6d\x62\x20\x3a\x88\xb7\xf1\xb0\x3d\x92\x7d\xde\x5c\x4b\xef\x4d\x8e\xec\xfb\x95\x35\x46\x70\x10\xd9\xf9\xb4\xdf\x70\x61\x92\xdd\xdf\x51\x7d\x59\xf9\xbe\x0b\x39\x19\xbd\x4c\x6a\xcb\xdd\x36\x1e\xc9\xf3\xef\xf6\xc8\xf5\x7b\xaa\x34\x72\xec\xe0\x2c\x86\x2e\x97\x9d\xa7\xc6\x79\x9b\xc5\xde\x77\x57\x44\xbe\xff\xbd\xa2\x9e\xbf\x8b\xc7\x05\x1a\xb6\x50\xfd\xc1\x82\x5c\xbb\xfb\xe3\x60\x74\x29\xf7\x5c\x9c\x0d\xb3\xdd\xf6\xa5\x38\x5b\x86\x2e\xd0\xad\x9a\x44\xc9\x0c\xd6\xb6\x1e\xc2\x72\xf0\xc4\x6b\xd8\x75\x73\x20\x86\xc1\xa5\x24\xc1\x99\x20\xb6\x5f\xaf\xc2\xd3\xa2\x03\xac\xe1\x85\xf2\x5c\xbc\x24\xaa\xca\xdb\xc9\xe4\x24\x44\x97\x51\x72\xcf\xce\x15\x9b\xfd\xb3\x24\x5b\x9d\x8e\xdf\x65\xef\x34\x1a\x8e\xcc\x8c\x9c\xb8\x61\xd7\xcd\xd0\xd5\xc4\xec\xdf\xdc\xad\x26\xa5\xae\xd8\xe6\x7f\x03\x00\xc9\xf3\x53\xef\x16\x1a\x00\x00")
 
 func templatesWebTmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -104,7 +125,7 @@ func templatesWebTmpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/web.tmpl", size: 3664, mode: os.FileMode(420), modTime: time.Unix(1492122792, 0)}
+	info := bindataFileInfo{name: "templates/web.tmpl", size: 6678, mode: os.FileMode(420), modTime: time.Unix(1792271599, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -162,6 +183,7 @@ func AssetNames() []string {
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
 	"templates/book.tmpl": templatesBookTmpl,
+	"templates/slides.tmpl": templatesSlidesTmpl,
 	"templates/web.tmpl": templatesWebTmpl,
 }
 
@@ -207,6 +229,7 @@ type bintree struct {
 var _bintree = &bintree{nil, map[string]*bintree{
 	"templates": &bintree{nil, map[string]*bintree{
 		"book.tmpl": &bintree{templatesBookTmpl, map[string]*bintree{}},
+		"slides.tmpl": &bintree{templatesSlidesTmpl, map[string]*bintree{}},
 		"web.tmpl": &bintree{templatesWebTmpl, map[string]*bintree{}},
 	}},
 }}
@@ -0,0 +1,139 @@
+package static
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var headings = regexp.MustCompile(`(?is)<h([1-6])(\s[^>]*)?>(.*?)</h[1-6]>`)
+var ids = regexp.MustCompile(`(?i)\sid\s*=\s*"([^"]*)"`)
+var tags = regexp.MustCompile(`<[^>]*>`)
+
+// A heading in the table of contents, nested beneath the nearest preceding
+// heading with a higher level.
+type Heading struct {
+	Level    int
+	ID       string
+	Title    string
+	Children []*Heading
+}
+
+// The anchor at the start of a file in book mode, along with the anchors github
+// would generate for the file alone mapped onto those used in the book.  When
+// chapters are separate documents, the doc names the document holding it.
+type chapter struct {
+	id  string
+	doc string
+	ids map[string]string
+}
+
+// This assigns anchors to headings and records them in document order, so
+// that a table of contents can be built once every file has been processed.
+//
+// Anchors are unique across every file passed through the same instance, and
+// when files are started with begin each is recorded as a chapter.
+type anchors struct {
+	seen     map[string]bool
+	headings []*Heading
+	local    *anchors
+	current  *chapter
+	chapters map[string]*chapter
+}
+
+// This converts heading text into an anchor matching the ids generated by
+// github, so links written against the markdown on github continue to work.
+//
+// The text is lowercased, punctuation is removed, and each space becomes a
+// hyphen, without collapsing repeated hyphens.
+func slug(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsNumber(r), unicode.IsMark(r), r == '_', r == '-':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	if b.Len() == 0 {
+		return "section"
+	}
+	return b.String()
+}
+
+// This reserves an anchor, adding a numeric suffix if it has already been
+// used, which matches how github deduplicates repeated headings.
+func (a *anchors) unique(id string) string {
+	if a.seen == nil {
+		a.seen = make(map[string]bool)
+	}
+	u := id
+	for i := 1; a.seen[u]; i++ {
+		u = id + "-" + strconv.Itoa(i)
+	}
+	a.seen[u] = true
+	return u
+}
+
+// This starts a new file, reserving a unique anchor for the start of it from
+// the supplied name, which is returned.
+func (a *anchors) begin(file, name string) string {
+	if a.chapters == nil {
+		a.chapters = make(map[string]*chapter)
+	}
+	a.local = &anchors{}
+	a.current = &chapter{id: a.unique("chapter-" + slug(name)), ids: make(map[string]string)}
+	a.chapters[file] = a.current
+	return a.current.id
+}
+
+// This adds an id to every heading in the html that does not already have
+// one, and records each heading with its plain text title.  Within a chapter
+// the id github would have used is mapped to the id that was assigned.
+func (a *anchors) anchor(b []byte) []byte {
+	return headings.ReplaceAllFunc(b, func(h []byte) []byte {
+		s := headings.FindSubmatch(h)
+		l, _ := strconv.Atoi(string(s[1]))
+		t := strings.TrimSpace(html.UnescapeString(tags.ReplaceAllString(string(s[3]), "")))
+		attrs := string(s[2])
+		var id, local string
+		if m := ids.FindStringSubmatch(attrs); m != nil {
+			id, local = m[1], m[1]
+			a.unique(id)
+		} else {
+			local = slug(t)
+			id = a.unique(local)
+			attrs = fmt.Sprintf(` id="%s"`, id) + attrs
+		}
+		if a.local != nil {
+			a.current.ids[a.local.unique(local)] = id
+		}
+		a.headings = append(a.headings, &Heading{Level: l, ID: id, Title: t})
+		return []byte(fmt.Sprintf("<h%d%s>%s</h%d>", l, attrs, s[3], l))
+	})
+}
+
+// This nests the recorded headings by level, so that each heading is a child
+// of the nearest preceding heading with a lower level number.
+func (a *anchors) toc() []*Heading {
+	var root []*Heading
+	var stack []*Heading
+	for _, h := range a.headings {
+		h.Children = nil
+		for len(stack) > 0 && stack[len(stack)-1].Level >= h.Level {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			root = append(root, h)
+		} else {
+			p := stack[len(stack)-1]
+			p.Children = append(p.Children, h)
+		}
+		stack = append(stack, h)
+	}
+	return root
+}
@@ -0,0 +1,28 @@
+package static
+
+import "testing"
+
+func TestAnchors(t *testing.T) {
+	a := &anchors{}
+	b := a.anchor([]byte(`<h1>Getting <em>Started</em></h1><p>text</p><h2 class="x">Install &amp; Run</h2><h3>Notes</h3><h2 id="kept">Usage</h2>`))
+	b = append(b, a.anchor([]byte(`<h1>Getting Started</h1>`))...)
+
+	// headings without an id gain a unique anchor, and existing ids are kept
+	expect := `<h1 id="getting-started">Getting <em>Started</em></h1><p>text</p><h2 id="install--run" class="x">Install &amp; Run</h2><h3 id="notes">Notes</h3><h2 id="kept">Usage</h2><h1 id="getting-started-1">Getting Started</h1>`
+	if string(b) != expect {
+		t.Errorf("unexpected html:\n%s\n%s", b, expect)
+	}
+
+	// slugs match the anchors generated by github
+	for in, out := range map[string]string{"What's New?": "whats-new", "Café  au lait": "café--au-lait", "snake_case-name": "snake_case-name", "C++ / Go 1.8": "c--go-18"} {
+		if slug(in) != out {
+			t.Errorf("expected %s from %q, got %s", out, in, slug(in))
+		}
+	}
+
+	// headings nest beneath the nearest heading with a lower level
+	toc := a.toc()
+	if len(toc) != 2 || len(toc[0].Children) != 2 || toc[0].Children[0].Title != "Install & Run" || toc[0].Children[0].Children[0].ID != "notes" || toc[1].ID != "getting-started-1" {
+		t.Errorf("unexpected table of contents %#v", toc)
+	}
+}
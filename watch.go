@@ -0,0 +1,120 @@
+package static
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var remove = os.Remove
+var tick = time.Tick
+var interval = 500 * time.Millisecond
+
+// This captures the modified time of every markdown file and directory layout
+// under the input path, and of every template file that has been supplied.  In
+// web mode every asset that would be copied is included, while the output is
+// skipped so that writing it never triggers another rebuild.
+//
+// Errors are ignored, since a file that cannot be read now may be readable by
+// the next poll, and a missing file is treated as deleted.
+func (m *Markdown) snapshot() map[string]time.Time {
+	s := make(map[string]time.Time)
+	for _, file := range m.templates() {
+		if f, e := stat(file); e == nil {
+			s[file] = f.ModTime()
+		}
+	}
+	filepath.Walk(m.Input, func(file string, f os.FileInfo, e error) error {
+		if e == nil && f.IsDir() && file != m.Input && m.output(file) {
+			return filepath.SkipDir
+		}
+		if e == nil && f.Mode().IsRegular() && (m.valid(file) || (m.Web && (filepath.Base(file) == layoutFile || m.include(file)))) {
+			s[file] = f.ModTime()
+		}
+		return nil
+	})
+	return s
+}
+
+// This rebuilds the output for the set of changed files.
+//
+// The list of files is collected again so that new and deleted files are
+// accounted for, and the error from any prior build is cleared.
+//
+// In web mode the pages matching the changed files are rendered, along with
+// any other pages that are stale, and the html for deleted files is removed,
+// before the search index, feeds and sitemap are written again and any new or
+// modified assets are copied.  Book and epub
+// modes always rebuild the entire output, and man pages are rebuilt when
+// stale.
+func (m *Markdown) rebuild(r Renderer, changed map[string]bool) {
+	m.err = nil
+	m.files, m.assets, m.layouts = nil, nil, nil
+	m.errors(filepath.Walk(m.Input, m.walk))
+	m.L.Info("Rebuilding %d changed files", len(changed))
+	if m.Man {
+		m.errors(m.man(r))
+	} else if m.Web {
+		m.errors(m.web(r, changed))
+		m.copy()
+	} else {
+		m.errors(m.single(r))
+	}
+}
+
+// This checks whether the file is in the current list of files.
+func (m *Markdown) exists(file string) bool {
+	for i := range m.files {
+		if m.files[i] == file {
+			return true
+		}
+	}
+	return false
+}
+
+// This polls the input path and template for changes, which avoids depending
+// on operating system specific notifications.
+//
+// Changes are collected until a poll finds nothing new, so that a burst of
+// saves from an editor produces a single rebuild.  When serving, browsers are
+// notified after each rebuild.
+//
+// It runs until the context of the run is done or the ticker is closed, which
+// without a context means forever.
+func (m *Markdown) watch(r Renderer) {
+	last := m.snapshot()
+	changed := make(map[string]bool)
+	m.L.Info("Watching %s for changes", m.Input)
+	ticks := tick(interval)
+	for {
+		select {
+		case <-m.context().Done():
+			return
+		case _, ok := <-ticks:
+			if !ok {
+				return
+			}
+		}
+		next := m.snapshot()
+		var burst bool
+		for file, t := range next {
+			if p, ok := last[file]; !ok || !p.Equal(t) {
+				changed[file], burst = true, true
+			}
+		}
+		for file := range last {
+			if _, ok := next[file]; !ok {
+				changed[file], burst = true, true
+			}
+		}
+		last = next
+		if burst || len(changed) == 0 {
+			continue
+		}
+		m.rebuild(r, changed)
+		changed = make(map[string]bool)
+		if m.reload != nil {
+			m.reload()
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package static
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMarkdownWatch(t *testing.T) {
+	d, cleanup := fixture(t, map[string]string{"page.md": "before"})
+	defer cleanup()
+	file := filepath.Join(d, "page.md")
+
+	// supply our own ticker so we control each poll
+	c := make(chan time.Time)
+	tick = func(time.Duration) <-chan time.Time { return c }
+	m := &Markdown{L: &mockLogger{}, Input: d, Web: true, Watch: true, Search: true}
+	done := make(chan error)
+	go func() { done <- m.Run(Operation(func(b []byte) []byte { return b })) }()
+	c <- time.Now()
+
+	// a modification is rebuilt once a poll finds no further changes, along
+	// with the search index
+	if e := ioutil.WriteFile(file, []byte("after"), 0644); e != nil {
+		t.Fatal(e)
+	}
+	os.Chtimes(file, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+	c <- time.Now()
+	c <- time.Now()
+	c <- time.Now()
+	if b, _ := ioutil.ReadFile(filepath.Join(d, "public", "page.html")); !bytes.Contains(b, []byte("after")) {
+		t.Errorf("expected rebuilt page, got %s", b)
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(d, "public", "search.js")); !bytes.Contains(b, []byte("after")) {
+		t.Errorf("expected rebuilt search index, got %s", b)
+	}
+
+	// a new asset is copied
+	ioutil.WriteFile(filepath.Join(d, "image.png"), []byte("png"), 0644)
+	c <- time.Now()
+	c <- time.Now()
+	c <- time.Now()
+	if _, e := os.Stat(filepath.Join(d, "public", "image.png")); e != nil {
+		t.Errorf("expected new asset to be copied: %v", e)
+	}
+
+	// a deleted file removes its output
+	os.Remove(file)
+	c <- time.Now()
+	c <- time.Now()
+	close(c)
+	if e := <-done; e != nil {
+		t.Error(e)
+	}
+	if _, e := os.Stat(filepath.Join(d, "public", "page.html")); !os.IsNotExist(e) {
+		t.Errorf("expected deleted page to be removed: %v", e)
+	}
+}